@@ -8,10 +8,9 @@
 package account
 
 import (
-	"fmt"
-
 	"github.com/dobyte/tencent-im/internal/core"
 	"github.com/dobyte/tencent-im/internal/enum"
+	"github.com/dobyte/tencent-im/internal/sign"
 	"github.com/dobyte/tencent-im/internal/types"
 )
 
@@ -25,9 +24,10 @@ const (
 	commandKickAccount               = "kick"
 	commandQueryAccountsOnlineStatus = "query_online_status"
 
-	batchImportAccountsLimit = 100 // 导入账号限制
-	batchDeleteAccountsLimit = 100 // 删除账号限制
-	batchCheckAccountsLimit  = 100 // 查询账号限制
+	batchImportAccountsLimit            = 100 // 导入账号限制
+	batchDeleteAccountsLimit            = 100 // 删除账号限制
+	batchCheckAccountsLimit             = 100 // 查询账号限制
+	batchQueryAccountsOnlineStatusLimit = 500 // 查询在线状态限制
 )
 
 type API interface {
@@ -41,6 +41,7 @@ type API interface {
 	// ImportAccounts 导入多个帐号
 	// 本接口用于批量将 App 自有帐号导入即时通信 IM 帐号系统，
 	// 为该帐号创建一个对应的内部 ID，使该帐号能够使用即时通信 IM 服务。
+	// 超过单次导入限制时自动分批导入，不合法的帐号不会发送至后台，直接计入导入失败的帐号列表。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/4919
 	ImportAccounts(userIds ...string) (failUserIds []string, err error)
@@ -54,6 +55,7 @@ type API interface {
 
 	// DeleteAccounts 删除多个帐号
 	// 仅支持删除套餐包类型为 IM 体验版的帐号，其他类型的账号（如：TRTC、白板、专业版、旗舰版）无法删除。
+	// 超过单次删除限制时自动分批删除，单个帐号的删除结果通过结果项返回。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/36443
 	DeleteAccounts(userIds ...string) (results []*DeleteResult, err error)
@@ -67,13 +69,22 @@ type API interface {
 
 	// CheckAccounts 查询多个帐号导入状态
 	// 用于查询自有帐号是否已导入即时通信 IM，支持批量查询。
+	// 超过单次查询限制时自动分批查询。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/38417
 	CheckAccounts(userIds ...string) (results []*CheckResult, err error)
 
+	// CheckAccountsExist 查询多个帐号是否已导入
+	// 本方法拓展于“查询多个帐号导入状态（CheckAccounts）”方法。
+	// 返回 UserID => 是否已导入 的映射，超过单次查询限制时自动分批查询。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/38417
+	CheckAccountsExist(userIds ...string) (exists map[string]bool, err error)
+
 	// KickAccount 使帐号登录状态失效
 	// 本接口适用于将 App 用户帐号的登录状态（例如 UserSig）失效。
 	// 例如，开发者判断一个用户为恶意帐号后，可以调用本接口将该用户当前的登录状态失效，这样用户使用历史 UserSig 登录即时通信 IM 会失败。
+	// 帐号不存在时返回的错误满足 errors.Is(err, ErrAccountNotFound)，仅关心帐号最终处于登出状态时可视为成功。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/3853
 	KickAccount(userId string) (err error)
@@ -86,7 +97,7 @@ type API interface {
 	GetAccountOnlineState(userId string, isNeedDetail ...bool) (*OnlineStatusResult, error)
 
 	// GetAccountsOnlineState 查询多个帐号在线状态
-	// 获取用户当前的登录状态。
+	// 获取用户当前的登录状态。超过单次查询限制时自动分批查询。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/2566
 	GetAccountsOnlineState(userIds []string, isNeedDetail ...bool) (ret *OnlineStatusRet, err error)
@@ -106,6 +117,11 @@ func NewAPI(client core.Client) API {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1608
 func (a *api) ImportAccount(account *Account) (err error) {
+	if account == nil || account.UserId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if err = a.client.Post(serviceAccount, commandImportAccount, account, &types.ActionBaseResp{}); err != nil {
 		return
 	}
@@ -116,25 +132,39 @@ func (a *api) ImportAccount(account *Account) (err error) {
 // ImportAccounts 导入多个帐号
 // 本接口用于批量将 App 自有帐号导入即时通信 IM 帐号系统，
 // 为该帐号创建一个对应的内部 ID，使该帐号能够使用即时通信 IM 服务。
+// 超过单次导入限制时自动分批导入，不合法的帐号不会发送至后台，直接计入导入失败的帐号列表。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/4919
 func (a *api) ImportAccounts(userIds ...string) (failUserIds []string, err error) {
-	if c := len(userIds); c == 0 {
+	if len(userIds) == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
 		return
-	} else if c > batchImportAccountsLimit {
-		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of imported accounts cannot exceed %d", batchImportAccountsLimit))
-		return
 	}
 
-	req := &importAccountsReq{UserIds: userIds}
-	resp := &importAccountsResp{}
-
-	if err = a.client.Post(serviceAccount, commandImportAccounts, req, resp); err != nil {
-		return
+	validUserIds := make([]string, 0, len(userIds))
+	for _, userId := range userIds {
+		if sign.ValidateUserID(userId) != nil {
+			failUserIds = append(failUserIds, userId)
+		} else {
+			validUserIds = append(validUserIds, userId)
+		}
 	}
 
-	failUserIds = resp.FailUserIds
+	for i := 0; i < len(validUserIds); i += batchImportAccountsLimit {
+		end := i + batchImportAccountsLimit
+		if end > len(validUserIds) {
+			end = len(validUserIds)
+		}
+
+		req := &importAccountsReq{UserIds: validUserIds[i:end]}
+		resp := &importAccountsResp{}
+
+		if err = a.client.Post(serviceAccount, commandImportAccounts, req, resp); err != nil {
+			return
+		}
+
+		failUserIds = append(failUserIds, resp.FailUserIds...)
+	}
 
 	return
 }
@@ -161,29 +191,34 @@ func (a *api) DeleteAccount(userId string) (err error) {
 
 // DeleteAccounts 删除多个帐号
 // 仅支持删除套餐包类型为 IM 体验版的帐号，其他类型的账号（如：TRTC、白板、专业版、旗舰版）无法删除。
+// 超过单次删除限制时自动分批删除，单个帐号的删除结果通过结果项返回。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/36443
 func (a *api) DeleteAccounts(userIds ...string) (results []*DeleteResult, err error) {
-	if c := len(userIds); c == 0 {
+	if len(userIds) == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
 		return
-	} else if c > batchDeleteAccountsLimit {
-		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of deleted accounts cannot exceed %d", batchDeleteAccountsLimit))
-		return
 	}
 
-	req := &deleteAccountsReq{}
-	resp := &deleteAccountsResp{}
+	for i := 0; i < len(userIds); i += batchDeleteAccountsLimit {
+		end := i + batchDeleteAccountsLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
 
-	for _, userId := range userIds {
-		req.Deletes = append(req.Deletes, &accountItem{userId})
-	}
+		req := &deleteAccountsReq{}
+		resp := &deleteAccountsResp{}
 
-	if err = a.client.Post(serviceAccount, commandDeleteAccounts, req, resp); err != nil {
-		return
-	}
+		for _, userId := range userIds[i:end] {
+			req.Deletes = append(req.Deletes, &accountItem{userId})
+		}
 
-	results = resp.Results
+		if err = a.client.Post(serviceAccount, commandDeleteAccounts, req, resp); err != nil {
+			return
+		}
+
+		results = append(results, resp.Results...)
+	}
 
 	return
 }
@@ -214,29 +249,56 @@ func (a *api) CheckAccount(userId string) (bool, error) {
 
 // CheckAccounts 查询多个帐号导入状态.
 // 用于查询自有帐号是否已导入即时通信 IM，支持批量查询。
+// 超过单次查询限制时自动分批查询。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/38417
 func (a *api) CheckAccounts(userIds ...string) (results []*CheckResult, err error) {
-	if c := len(userIds); c == 0 {
+	if len(userIds) == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the account is not set")
 		return
-	} else if c > batchCheckAccountsLimit {
-		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of checked accounts cannot exceed %d", batchCheckAccountsLimit))
-		return
 	}
 
-	req := &checkAccountsReq{}
-	resp := &checkAccountsResp{}
+	for i := 0; i < len(userIds); i += batchCheckAccountsLimit {
+		end := i + batchCheckAccountsLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+
+		req := &checkAccountsReq{}
+		resp := &checkAccountsResp{}
 
-	for _, userId := range userIds {
-		req.Checks = append(req.Checks, &accountItem{userId})
+		for _, userId := range userIds[i:end] {
+			req.Checks = append(req.Checks, &accountItem{userId})
+		}
+
+		if err = a.client.Post(serviceAccount, commandCheckAccounts, req, resp); err != nil {
+			return
+		}
+
+		results = append(results, resp.Results...)
 	}
 
-	if err = a.client.Post(serviceAccount, commandCheckAccounts, req, resp); err != nil {
+	return
+}
+
+// CheckAccountsExist 查询多个帐号是否已导入
+// 本方法拓展于“查询多个帐号导入状态（CheckAccounts）”方法。
+// 返回 UserID => 是否已导入 的映射，超过单次查询限制时自动分批查询。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/38417
+func (a *api) CheckAccountsExist(userIds ...string) (exists map[string]bool, err error) {
+	results, err := a.CheckAccounts(userIds...)
+	if err != nil {
 		return
 	}
 
-	results = resp.Results
+	exists = make(map[string]bool, len(results))
+	for _, result := range results {
+		if result.ResultCode != enum.SuccessCode {
+			return nil, core.NewError(result.ResultCode, result.ResultInfo)
+		}
+		exists[result.UserId] = result.Status == ImportedStatusYes
+	}
 
 	return
 }
@@ -244,6 +306,7 @@ func (a *api) CheckAccounts(userIds ...string) (results []*CheckResult, err erro
 // KickAccount 失效帐号登录状态
 // 本接口适用于将 App 用户帐号的登录状态（例如 UserSig）失效。
 // 例如，开发者判断一个用户为恶意帐号后，可以调用本接口将该用户当前的登录状态失效，这样用户使用历史 UserSig 登录即时通信 IM 会失败。
+// 帐号不存在时返回的错误满足 errors.Is(err, ErrAccountNotFound)，仅关心帐号最终处于登出状态时可视为成功。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3853
 func (a *api) KickAccount(userId string) (err error) {
@@ -281,24 +344,36 @@ func (a *api) GetAccountOnlineState(userId string, isNeedDetail ...bool) (*Onlin
 }
 
 // GetAccountsOnlineState 查询多个帐号在线状态
-// 获取用户当前的登录状态。
+// 获取用户当前的登录状态。超过单次查询限制时自动分批查询。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/2566
 func (a *api) GetAccountsOnlineState(userIds []string, isNeedDetail ...bool) (ret *OnlineStatusRet, err error) {
-	req := &queryAccountsOnlineStatusReq{UserIds: userIds}
-	resp := &queryAccountsOnlineStatusResp{}
-
-	if len(isNeedDetail) > 0 && isNeedDetail[0] {
-		req.IsNeedDetail = 1
-	}
-
-	if err = a.client.Post(serviceOpenIM, commandQueryAccountsOnlineStatus, req, resp); err != nil {
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
 		return
 	}
 
-	ret = &OnlineStatusRet{
-		Results: resp.Results,
-		Errors:  resp.Errors,
+	ret = &OnlineStatusRet{}
+
+	for i := 0; i < len(userIds); i += batchQueryAccountsOnlineStatusLimit {
+		end := i + batchQueryAccountsOnlineStatusLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+
+		req := &queryAccountsOnlineStatusReq{UserIds: userIds[i:end]}
+		resp := &queryAccountsOnlineStatusResp{}
+
+		if len(isNeedDetail) > 0 && isNeedDetail[0] {
+			req.IsNeedDetail = 1
+		}
+
+		if err = a.client.Post(serviceOpenIM, commandQueryAccountsOnlineStatus, req, resp); err != nil {
+			return nil, err
+		}
+
+		ret.Results = append(ret.Results, resp.Results...)
+		ret.Errors = append(ret.Errors, resp.Errors...)
 	}
 
 	return
@@ -7,6 +7,10 @@
 
 package account
 
+import (
+	"github.com/dobyte/tencent-im/internal/core"
+)
+
 // ImportedStatusType 导入状态
 type ImportedStatusType string
 
@@ -14,3 +18,6 @@ const (
 	ImportedStatusNo  ImportedStatusType = "NotImported" // 未导入
 	ImportedStatusYes ImportedStatusType = "Imported"    // 已导入
 )
+
+// ErrAccountNotFound 请求的帐号不存在
+var ErrAccountNotFound = core.NewError(70107, "the account does not exist")
@@ -14,9 +14,9 @@ import (
 type (
 	// Account 导入单个账号
 	Account struct {
-		UserId   string `json:"Identifier"` // （必填）用户名，长度不超过32字节
-		Nickname string `json:"Nick"`       // （选填）用户昵称
-		FaceUrl  string `json:"FaceUrl"`    // （选填）用户头像 URL
+		UserId   string `json:"Identifier"`        // （必填）用户名，长度不超过32字节
+		Nickname string `json:"Nick,omitempty"`    // （选填）用户昵称
+		FaceUrl  string `json:"FaceUrl,omitempty"` // （选填）用户头像 URL
 	}
 
 	// 批量导入账号（参数）
@@ -11,8 +11,10 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -66,6 +68,12 @@ const (
 	EventAfterGroupInfoChanged
 )
 
+const (
+	StateActionLogin      = "Login"      // 上线（TCP 建立）
+	StateActionLogout     = "Logout"     // 下线（TCP 断开）
+	StateActionDisconnect = "Disconnect" // 网络断开（TCP 断开）
+)
+
 const (
 	ackSuccessStatus = "OK"
 	ackFailureStatus = "FAIL"
@@ -80,6 +88,16 @@ const (
 	queryContentType = "contenttype"
 )
 
+var (
+	errRepeatedAck = errors.New("the callback has already been acked")
+
+	// ErrInvalidAppId 回调请求的 SdkAppid 与配置不一致
+	ErrInvalidAppId = errors.New("invalid sdk appId")
+
+	// ErrUntrustedSource 回调请求的来源IP不在可信IP列表中
+	ErrUntrustedSource = errors.New("untrusted callback source")
+)
+
 type (
 	Event            int
 	EventHandlerFunc func(ack Ack, data interface{})
@@ -88,16 +106,75 @@ type (
 	}
 
 	Callback interface {
+		http.Handler
 		// Register 注册事件
 		Register(event Event, handler EventHandlerFunc)
 		// Listen 监听事件
 		Listen(w http.ResponseWriter, r *http.Request)
+		// SetTrustedIPs 设置可信的回调来源IP或IP网段，可配合运营管理的“获取服务器IP地址（GetIPList）”使用
+		SetTrustedIPs(ips ...string) error
+		// VerifySource 校验回调请求的 SdkAppid 及来源IP
+		VerifySource(r *http.Request) error
+
+		// OnBeforeGroupCreate 注册创建群组之前回调，返回nil表示允许创建
+		OnBeforeGroupCreate(fn func(data *BeforeGroupCreate) *BaseResp)
+		// OnAfterGroupCreate 注册创建群组之后回调
+		OnAfterGroupCreate(fn func(data *AfterGroupCreate))
+		// OnBeforeApplyJoinGroup 注册申请入群之前回调，返回nil表示允许申请
+		OnBeforeApplyJoinGroup(fn func(data *BeforeApplyJoinGroup) *BaseResp)
+		// OnBeforeInviteJoinGroup 注册拉人入群之前回调，返回nil表示允许全部成员入群
+		OnBeforeInviteJoinGroup(fn func(data *BeforeInviteJoinGroup) *BeforeInviteJoinGroupResp)
+		// OnAfterNewMemberJoinGroup 注册新成员入群之后回调
+		OnAfterNewMemberJoinGroup(fn func(data *AfterNewMemberJoinGroup))
+		// OnAfterMemberExitGroup 注册群成员离开之后回调
+		OnAfterMemberExitGroup(fn func(data *AfterMemberExitGroup))
+		// OnBeforeGroupMessageSend 注册群内发言之前回调，返回nil表示允许发言且不修改消息
+		OnBeforeGroupMessageSend(fn func(data *BeforeGroupMessageSend) *BeforeGroupMessageSendResp)
+		// OnAfterGroupMessageSend 注册群内发言之后回调
+		OnAfterGroupMessageSend(fn func(data *AfterGroupMessageSend))
+		// OnAfterGroupFull 注册群组满员之后回调
+		OnAfterGroupFull(fn func(data *AfterGroupFull))
+		// OnAfterGroupDestroyed 注册群组解散之后回调
+		OnAfterGroupDestroyed(fn func(data *AfterGroupDestroyed))
+		// OnAfterGroupInfoChanged 注册群组资料修改之后回调
+		OnAfterGroupInfoChanged(fn func(data *AfterGroupInfoChanged))
+
+		// OnBeforePrivateMessageSend 注册发单聊消息之前回调，返回nil表示允许发送且不修改消息
+		OnBeforePrivateMessageSend(fn func(data *BeforePrivateMessageSend) *BeforePrivateMessageSendResp)
+		// OnAfterPrivateMessageSend 注册发单聊消息之后回调
+		OnAfterPrivateMessageSend(fn func(data *AfterPrivateMessageSend))
+		// OnAfterPrivateMessageReport 注册单聊消息已读上报后回调
+		OnAfterPrivateMessageReport(fn func(data *AfterPrivateMessageReport))
+		// OnAfterPrivateMessageRevoke 注册单聊消息撤回后回调
+		OnAfterPrivateMessageRevoke(fn func(data *AfterPrivateMessageRevoke))
+
+		// OnStateChange 注册状态变更回调
+		OnStateChange(fn func(data *StateChange))
+
+		// OnBeforeFriendAdd 注册添加好友之前回调，返回nil表示允许添加全部好友
+		OnBeforeFriendAdd(fn func(data *BeforeFriendAdd) *BeforeFriendAddResp)
+		// OnBeforeFriendResponse 注册添加好友回应之前回调，返回nil表示允许全部回应
+		OnBeforeFriendResponse(fn func(data *BeforeFriendResponse) *BeforeFriendResponseResp)
+		// OnAfterFriendAdd 注册添加好友之后回调
+		OnAfterFriendAdd(fn func(data *AfterFriendAdd))
+		// OnAfterFriendDelete 注册删除好友之后回调
+		OnAfterFriendDelete(fn func(data *AfterFriendDelete))
+		// OnAfterBlacklistAdd 注册添加黑名单之后回调
+		OnAfterBlacklistAdd(fn func(data *AfterBlacklistAdd))
+		// OnAfterBlacklistDelete 注册删除黑名单之后回调
+		OnAfterBlacklistDelete(fn func(data *AfterBlacklistDelete))
+	}
+
+	// responder 回调应答
+	responder interface {
+		base() *BaseResp
 	}
 
 	callback struct {
-		appId    int
-		mu       sync.Mutex
-		handlers map[Event]EventHandlerFunc
+		appId      int
+		mu         sync.RWMutex
+		handlers   map[Event]EventHandlerFunc
+		trustedIPs []*net.IPNet
 	}
 
 	Ack interface {
@@ -110,7 +187,8 @@ type (
 	}
 
 	ack struct {
-		w http.ResponseWriter
+		w     http.ResponseWriter
+		acked bool
 	}
 )
 
@@ -128,13 +206,18 @@ func (c *callback) Register(event Event, handler EventHandlerFunc) {
 	c.mu.Unlock()
 }
 
+// ServeHTTP 实现 http.Handler 接口，可直接挂载到 HTTP 路由上
+func (c *callback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.Listen(w, r)
+}
+
 // Listen 监听事件
+// 事件处理器未进行应答时，将默认进行成功应答
 func (c *callback) Listen(w http.ResponseWriter, r *http.Request) {
-	a := newAck(w)
+	a := &ack{w: w}
 
-	appId, ok := c.GetQuery(r, queryAppId)
-	if !ok || appId != strconv.Itoa(c.appId) {
-		_ = a.AckFailure("invalid sdk appId")
+	if err := c.VerifySource(r); err != nil {
+		w.WriteHeader(http.StatusForbidden)
 		return
 	}
 
@@ -151,15 +234,27 @@ func (c *callback) Listen(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if event, data, err := c.parseCommand(command, body); err != nil {
+	event, data, err := c.parseCommand(command, body)
+	if err != nil {
 		_ = a.AckFailure(err.Error())
-	} else {
-		if fn, ok := c.handlers[event]; ok {
-			fn(a, data)
-			return
-		} else {
-			_ = a.AckSuccess(ackSuccessCode)
-		}
+		return
+	}
+
+	if sc, ok := data.(*StateChange); ok {
+		sc.Platform, _ = c.GetQuery(r, queryOptPlatform)
+		sc.ClientIP, _ = c.GetQuery(r, queryClientId)
+	}
+
+	c.mu.RLock()
+	fn, ok := c.handlers[event]
+	c.mu.RUnlock()
+
+	if ok {
+		fn(a, data)
+	}
+
+	if !a.acked {
+		_ = a.AckSuccess(ackSuccessCode)
 	}
 }
 
@@ -236,13 +331,76 @@ func (c *callback) parseCommand(command string, body []byte) (event Event, data
 		return 0, nil, errors.New("invalid callback command")
 	}
 
-	if err = json.Unmarshal(body, &data); err != nil {
+	if err = json.Unmarshal(body, data); err != nil {
 		return 0, nil, err
 	}
 
 	return event, data, nil
 }
 
+// SetTrustedIPs 设置可信的回调来源IP或IP网段
+// 未设置时不校验来源IP，传入空列表将清除已设置的可信IP
+// 来源IP取自请求的 RemoteAddr，服务部署在反向代理之后时请在代理层进行来源校验
+func (c *callback) SetTrustedIPs(ips ...string) error {
+	nets := make([]*net.IPNet, 0, len(ips))
+	for _, ip := range ips {
+		ip = strings.TrimSpace(ip)
+		if !strings.Contains(ip, "/") {
+			if v := net.ParseIP(ip); v != nil && v.To4() == nil {
+				ip += "/128"
+			} else {
+				ip += "/32"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, ipNet)
+	}
+
+	c.mu.Lock()
+	c.trustedIPs = nets
+	c.mu.Unlock()
+
+	return nil
+}
+
+// VerifySource 校验回调请求的 SdkAppid 及来源IP
+// SdkAppid 不一致时返回 ErrInvalidAppId，来源IP不可信时返回 ErrUntrustedSource
+func (c *callback) VerifySource(r *http.Request) error {
+	if appId, ok := c.GetQuery(r, queryAppId); !ok || appId != strconv.Itoa(c.appId) {
+		return ErrInvalidAppId
+	}
+
+	c.mu.RLock()
+	trustedIPs := c.trustedIPs
+	c.mu.RUnlock()
+
+	if len(trustedIPs) == 0 {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ErrUntrustedSource
+	}
+
+	for _, ipNet := range trustedIPs {
+		if ipNet.Contains(ip) {
+			return nil
+		}
+	}
+
+	return ErrUntrustedSource
+}
+
 // GetQuery 获取查询参数
 func (c *callback) GetQuery(r *http.Request, key string) (string, bool) {
 	if values, ok := r.URL.Query()[key]; ok {
@@ -252,13 +410,15 @@ func (c *callback) GetQuery(r *http.Request, key string) (string, bool) {
 	}
 }
 
-func newAck(w http.ResponseWriter) Ack {
-	return &ack{w}
-}
-
 // Ack 应答
 func (a *ack) Ack(resp interface{}) error {
+	if a.acked {
+		return errRepeatedAck
+	}
+	a.acked = true
+
 	b, _ := json.Marshal(resp)
+	a.w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	a.w.WriteHeader(http.StatusOK)
 	_, err := a.w.Write(b)
 	return err
@@ -287,3 +447,22 @@ func (a *ack) AckSuccess(code int, message ...string) error {
 
 	return a.Ack(resp)
 }
+
+// NewRejectResp 创建拒绝操作的应答，用于“之前回调”中拒绝本次操作
+func NewRejectResp(code int, message ...string) *BaseResp {
+	resp := &BaseResp{ActionStatus: ackSuccessStatus, ErrorCode: code}
+	if len(message) > 0 {
+		resp.ErrorInfo = message[0]
+	}
+
+	return resp
+}
+
+// ackResponse 使用事件处理器返回的应答进行应答
+func ackResponse(a Ack, resp responder) {
+	if b := resp.base(); b.ActionStatus == "" {
+		b.ActionStatus = ackSuccessStatus
+	}
+
+	_ = a.Ack(resp)
+}
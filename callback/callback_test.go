@@ -0,0 +1,113 @@
+package callback
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const appId = 1400000000
+
+func newRequest(query, remoteAddr string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/callback?"+query, nil)
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func TestVerifySourceAppId(t *testing.T) {
+	c := NewCallback(appId)
+
+	cases := []struct {
+		query string
+		err   error
+	}{
+		{"SdkAppid=1400000000", nil},
+		{"SdkAppid=1400000001", ErrInvalidAppId},
+		{"SdkAppid=", ErrInvalidAppId},
+		{"", ErrInvalidAppId},
+	}
+
+	for _, cs := range cases {
+		if err := c.VerifySource(newRequest(cs.query, "192.0.2.1:443")); err != cs.err {
+			t.Errorf("query %q: expected %v, got %v", cs.query, cs.err, err)
+		}
+	}
+}
+
+func TestVerifySourceEmptyTrustList(t *testing.T) {
+	c := NewCallback(appId)
+
+	if err := c.VerifySource(newRequest("SdkAppid=1400000000", "203.0.113.7:443")); err != nil {
+		t.Fatalf("expected any source to pass without trusted IPs, got %v", err)
+	}
+
+	if err := c.SetTrustedIPs("192.0.2.1"); err != nil {
+		t.Fatalf("set trusted IPs failed: %v", err)
+	}
+
+	if err := c.VerifySource(newRequest("SdkAppid=1400000000", "203.0.113.7:443")); err != ErrUntrustedSource {
+		t.Fatalf("expected ErrUntrustedSource, got %v", err)
+	}
+
+	if err := c.SetTrustedIPs(); err != nil {
+		t.Fatalf("clear trusted IPs failed: %v", err)
+	}
+
+	if err := c.VerifySource(newRequest("SdkAppid=1400000000", "203.0.113.7:443")); err != nil {
+		t.Fatalf("expected any source to pass after clearing trusted IPs, got %v", err)
+	}
+}
+
+func TestVerifySourceTrustedIPs(t *testing.T) {
+	c := NewCallback(appId)
+
+	if err := c.SetTrustedIPs("192.0.2.1", " 2001:db8::1 ", "198.51.100.0/24", "2001:db8:1::/48"); err != nil {
+		t.Fatalf("set trusted IPs failed: %v", err)
+	}
+
+	cases := []struct {
+		remoteAddr string
+		err        error
+	}{
+		{"192.0.2.1:443", nil},
+		{"192.0.2.2:443", ErrUntrustedSource},
+		{"[2001:db8::1]:443", nil},
+		{"[2001:db8::2]:443", ErrUntrustedSource},
+		{"198.51.100.25:443", nil},
+		{"198.51.101.25:443", ErrUntrustedSource},
+		{"[2001:db8:1:ffff::1]:443", nil},
+		{"[2001:db8:2::1]:443", ErrUntrustedSource},
+		{"192.0.2.1", nil},
+		{"2001:db8::1", nil},
+		{"not-an-ip", ErrUntrustedSource},
+		{"", ErrUntrustedSource},
+	}
+
+	for _, cs := range cases {
+		if err := c.VerifySource(newRequest("SdkAppid=1400000000", cs.remoteAddr)); err != cs.err {
+			t.Errorf("remote addr %q: expected %v, got %v", cs.remoteAddr, cs.err, err)
+		}
+	}
+}
+
+func TestSetTrustedIPsInvalid(t *testing.T) {
+	c := NewCallback(appId)
+
+	if err := c.SetTrustedIPs("192.0.2.1"); err != nil {
+		t.Fatalf("set trusted IPs failed: %v", err)
+	}
+
+	for _, ip := range []string{"not-an-ip", "192.0.2.0/33", "2001:db8::/129"} {
+		if err := c.SetTrustedIPs(ip); err == nil {
+			t.Errorf("%q: expected error", ip)
+		}
+	}
+
+	if err := c.VerifySource(newRequest("SdkAppid=1400000000", "192.0.2.1:443")); err != nil {
+		t.Fatalf("expected previous trusted IPs to be kept after a failed update, got %v", err)
+	}
+
+	if err := c.VerifySource(newRequest("SdkAppid=1400000000", "203.0.113.7:443")); err != ErrUntrustedSource {
+		t.Fatalf("expected ErrUntrustedSource, got %v", err)
+	}
+}
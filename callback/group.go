@@ -0,0 +1,97 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2021/5/27 14:24
+ * @Desc: 群组回调事件
+ */
+
+package callback
+
+// OnBeforeGroupCreate 注册创建群组之前回调
+// 返回nil表示允许创建，返回 NewRejectResp 创建的应答表示拒绝创建
+func (c *callback) OnBeforeGroupCreate(fn func(data *BeforeGroupCreate) *BaseResp) {
+	c.Register(EventBeforeGroupCreate, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforeGroupCreate)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnAfterGroupCreate 注册创建群组之后回调
+func (c *callback) OnAfterGroupCreate(fn func(data *AfterGroupCreate)) {
+	c.Register(EventAfterGroupCreate, func(ack Ack, data interface{}) {
+		fn(data.(*AfterGroupCreate))
+	})
+}
+
+// OnBeforeApplyJoinGroup 注册申请入群之前回调
+// 返回nil表示允许申请，返回 NewRejectResp 创建的应答表示拒绝申请
+func (c *callback) OnBeforeApplyJoinGroup(fn func(data *BeforeApplyJoinGroup) *BaseResp) {
+	c.Register(EventBeforeApplyJoinGroup, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforeApplyJoinGroup)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnBeforeInviteJoinGroup 注册拉人入群之前回调
+// 返回nil表示允许全部成员入群，可通过应答的 RefusedMemberUserIds 拒绝部分成员入群
+func (c *callback) OnBeforeInviteJoinGroup(fn func(data *BeforeInviteJoinGroup) *BeforeInviteJoinGroupResp) {
+	c.Register(EventBeforeInviteJoinGroup, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforeInviteJoinGroup)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnAfterNewMemberJoinGroup 注册新成员入群之后回调
+func (c *callback) OnAfterNewMemberJoinGroup(fn func(data *AfterNewMemberJoinGroup)) {
+	c.Register(EventAfterNewMemberJoinGroup, func(ack Ack, data interface{}) {
+		fn(data.(*AfterNewMemberJoinGroup))
+	})
+}
+
+// OnAfterMemberExitGroup 注册群成员离开之后回调
+func (c *callback) OnAfterMemberExitGroup(fn func(data *AfterMemberExitGroup)) {
+	c.Register(EventAfterMemberExitGroup, func(ack Ack, data interface{}) {
+		fn(data.(*AfterMemberExitGroup))
+	})
+}
+
+// OnBeforeGroupMessageSend 注册群内发言之前回调
+// 返回nil表示允许发言且不修改消息，可通过应答的 ErrorCode 拒绝发言或通过 MsgBody 修改消息
+func (c *callback) OnBeforeGroupMessageSend(fn func(data *BeforeGroupMessageSend) *BeforeGroupMessageSendResp) {
+	c.Register(EventBeforeGroupMessageSend, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforeGroupMessageSend)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnAfterGroupMessageSend 注册群内发言之后回调
+func (c *callback) OnAfterGroupMessageSend(fn func(data *AfterGroupMessageSend)) {
+	c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+		fn(data.(*AfterGroupMessageSend))
+	})
+}
+
+// OnAfterGroupFull 注册群组满员之后回调
+func (c *callback) OnAfterGroupFull(fn func(data *AfterGroupFull)) {
+	c.Register(EventAfterGroupFull, func(ack Ack, data interface{}) {
+		fn(data.(*AfterGroupFull))
+	})
+}
+
+// OnAfterGroupDestroyed 注册群组解散之后回调
+func (c *callback) OnAfterGroupDestroyed(fn func(data *AfterGroupDestroyed)) {
+	c.Register(EventAfterGroupDestroyed, func(ack Ack, data interface{}) {
+		fn(data.(*AfterGroupDestroyed))
+	})
+}
+
+// OnAfterGroupInfoChanged 注册群组资料修改之后回调
+func (c *callback) OnAfterGroupInfoChanged(fn func(data *AfterGroupInfoChanged)) {
+	c.Register(EventAfterGroupInfoChanged, func(ack Ack, data interface{}) {
+		fn(data.(*AfterGroupInfoChanged))
+	})
+}
@@ -0,0 +1,42 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2021/5/27 14:24
+ * @Desc: 单聊回调事件
+ */
+
+package callback
+
+// OnBeforePrivateMessageSend 注册发单聊消息之前回调
+// 返回nil表示允许发送且不修改消息。
+// 应答的 ErrorCode 为非0值时拒绝发送该消息；设置应答的 MsgBody 或 CloudCustomData 时将使用修改后的消息下发给接收方。
+// 仅发单聊消息之前回调支持修改消息，发单聊消息之后回调的应答不会影响消息的下发。
+func (c *callback) OnBeforePrivateMessageSend(fn func(data *BeforePrivateMessageSend) *BeforePrivateMessageSendResp) {
+	c.Register(EventBeforePrivateMessageSend, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforePrivateMessageSend)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnAfterPrivateMessageSend 注册发单聊消息之后回调
+// 该回调仅用于通知，无法拒绝或修改已发送的消息
+func (c *callback) OnAfterPrivateMessageSend(fn func(data *AfterPrivateMessageSend)) {
+	c.Register(EventAfterPrivateMessageSend, func(ack Ack, data interface{}) {
+		fn(data.(*AfterPrivateMessageSend))
+	})
+}
+
+// OnAfterPrivateMessageReport 注册单聊消息已读上报后回调
+func (c *callback) OnAfterPrivateMessageReport(fn func(data *AfterPrivateMessageReport)) {
+	c.Register(EventAfterPrivateMessageReport, func(ack Ack, data interface{}) {
+		fn(data.(*AfterPrivateMessageReport))
+	})
+}
+
+// OnAfterPrivateMessageRevoke 注册单聊消息撤回后回调
+func (c *callback) OnAfterPrivateMessageRevoke(fn func(data *AfterPrivateMessageRevoke)) {
+	c.Register(EventAfterPrivateMessageRevoke, func(ack Ack, data interface{}) {
+		fn(data.(*AfterPrivateMessageRevoke))
+	})
+}
@@ -0,0 +1,60 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2021/5/27 14:24
+ * @Desc: 关系链回调事件
+ */
+
+package callback
+
+// OnBeforeFriendAdd 注册添加好友之前回调
+// 返回nil表示允许添加全部好友，可通过应答的 Results 拒绝添加部分好友
+func (c *callback) OnBeforeFriendAdd(fn func(data *BeforeFriendAdd) *BeforeFriendAddResp) {
+	c.Register(EventBeforeFriendAdd, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforeFriendAdd)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnBeforeFriendResponse 注册添加好友回应之前回调
+// 返回nil表示允许全部回应，可通过应答的 Results 拒绝部分回应
+func (c *callback) OnBeforeFriendResponse(fn func(data *BeforeFriendResponse) *BeforeFriendResponseResp) {
+	c.Register(EventBeforeFriendResponse, func(ack Ack, data interface{}) {
+		if resp := fn(data.(*BeforeFriendResponse)); resp != nil {
+			ackResponse(ack, resp)
+		}
+	})
+}
+
+// OnAfterFriendAdd 注册添加好友之后回调
+// PairList 中的每一项表示 From_Account 的好友表中增加了 To_Account
+func (c *callback) OnAfterFriendAdd(fn func(data *AfterFriendAdd)) {
+	c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+		fn(data.(*AfterFriendAdd))
+	})
+}
+
+// OnAfterFriendDelete 注册删除好友之后回调
+// PairList 中的每一项表示 From_Account 的好友表中删除了 To_Account
+func (c *callback) OnAfterFriendDelete(fn func(data *AfterFriendDelete)) {
+	c.Register(EventAfterFriendDelete, func(ack Ack, data interface{}) {
+		fn(data.(*AfterFriendDelete))
+	})
+}
+
+// OnAfterBlacklistAdd 注册添加黑名单之后回调
+// PairList 中的每一项表示 From_Account 的黑名单中添加了 To_Account
+func (c *callback) OnAfterBlacklistAdd(fn func(data *AfterBlacklistAdd)) {
+	c.Register(EventAfterBlacklistAdd, func(ack Ack, data interface{}) {
+		fn(data.(*AfterBlacklistAdd))
+	})
+}
+
+// OnAfterBlacklistDelete 注册删除黑名单之后回调
+// PairList 中的每一项表示 From_Account 的黑名单中删除了 To_Account
+func (c *callback) OnAfterBlacklistDelete(fn func(data *AfterBlacklistDelete)) {
+	c.Register(EventAfterBlacklistDelete, func(ack Ack, data interface{}) {
+		fn(data.(*AfterBlacklistDelete))
+	})
+}
@@ -0,0 +1,16 @@
+/**
+ * @Author: fuxiao
+ * @Author: 576101059@qq.com
+ * @Date: 2021/5/27 14:24
+ * @Desc: 在线状态回调事件
+ */
+
+package callback
+
+// OnStateChange 注册状态变更回调
+// Info.Action 为 StateActionLogin、StateActionLogout 或 StateActionDisconnect，Platform 为触发本次回调的客户端平台
+func (c *callback) OnStateChange(fn func(data *StateChange)) {
+	c.Register(EventStateChange, func(ack Ack, data interface{}) {
+		fn(data.(*StateChange))
+	})
+}
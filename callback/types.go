@@ -28,6 +28,8 @@ type (
 		KickedDevice []struct {
 			Platform string `json:"Platform"` // 被踢下线的设备的平台类型，可能的取值有"iOS", "Android", "Web", "Windows", "iPad", "Mac", "Linux"。
 		} `json:"KickedDevice"` // 此字段表示其他被踢下线的设备的信息
+		Platform string `json:"-"` // 触发本次回调的客户端平台，取自回调请求的 OptPlatform 参数
+		ClientIP string `json:"-"` // 触发本次回调的客户端 IP，取自回调请求的 ClientIP 参数
 	}
 
 	// BeforeFriendAdd 添加好友之前回调
@@ -77,7 +79,7 @@ type (
 	// BeforeFriendResponseResp 添加好友之前回调应答
 	BeforeFriendResponseResp struct {
 		BaseResp
-		Results []*BeforeFriendAddResult `json:"ResultItem"` // App 后台的处理结果
+		Results []*BeforeFriendResponseResult `json:"ResultItem"` // App 后台的处理结果
 	}
 
 	// BeforeFriendResponseResult App后台的处理结果
@@ -90,6 +92,7 @@ type (
 	// AfterFriendAdd 添加好友之后
 	AfterFriendAdd struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		EventTime       int64  `json:"EventTime"`       // 触发本次回调的时间戳，单位为毫秒
 		ClientCmd       string `json:"ClientCmd"`       // 触发回调的命令字：加好友请求，合理的取值如下：friend_add、FriendAdd; 加好友回应，合理的取值如下：friend_response、FriendResponse
 		AdminUserId     string `json:"Admin_Account"`   // 如果当前请求是后台触发的加好友请求，则该字段被赋值为管理员帐号；否则为空
 		ForceFlag       int    `json:"ForceFlag"`       // 管理员强制加好友标记：1 表示强制加好友；0 表示常规加好友方式
@@ -103,6 +106,7 @@ type (
 	// AfterFriendDelete 删除好友之后回调
 	AfterFriendDelete struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		EventTime       int64  `json:"EventTime"`       // 触发本次回调的时间戳，单位为毫秒
 		PairList        []struct {
 			FromUserId string `json:"From_Account"` // From_Account 的好友表中删除了 To_Account
 			ToUserId   string `json:"To_Account"`   // To_Account 从 From_Account 的好友表中删除
@@ -112,6 +116,7 @@ type (
 	// AfterBlacklistAdd 添加黑名单之后回调
 	AfterBlacklistAdd struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		EventTime       int64  `json:"EventTime"`       // 触发本次回调的时间戳，单位为毫秒
 		PairList        []struct {
 			FromUserId string `json:"From_Account"` // From_Account 的黑名单列表中添加了 To_Account
 			ToUserId   string `json:"To_Account"`   // To_Account 被加入到 From_Account 的黑名单列表中
@@ -121,6 +126,7 @@ type (
 	// AfterBlacklistDelete 删除黑名单之后回调
 	AfterBlacklistDelete struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		EventTime       int64  `json:"EventTime"`       // 触发本次回调的时间戳，单位为毫秒
 		PairList        []struct {
 			FromUserId string `json:"From_Account"` // From_Account 的黑名单列表中删除了 To_Account
 			ToUserId   string `json:"To_Account"`   // To_Account 从 From_Account 的黑名单列表中删除
@@ -186,6 +192,7 @@ type (
 	// BeforeGroupCreate 创建群组之前回调
 	BeforeGroupCreate struct {
 		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64  `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		OperatorUserId  string `json:"Operator_Account"` // 操作者
 		OwnerUserId     string `json:"Owner_Account"`    // 群主
 		Type            string `json:"Type"`             // 群组类型
@@ -199,6 +206,7 @@ type (
 	// AfterGroupCreate 创建群组之后回调
 	AfterGroupCreate struct {
 		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64  `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		OperatorUserId  string `json:"Operator_Account"` // 操作者
 		OwnerUserId     string `json:"Owner_Account"`    // 群主
 		GroupId         string `json:"GroupId"`          // 群ID
@@ -217,6 +225,7 @@ type (
 	// BeforeApplyJoinGroup 申请入群之前回调
 	BeforeApplyJoinGroup struct {
 		CallbackCommand string `json:"CallbackCommand"`   // 回调命令
+		EventTime       int64  `json:"EventTime"`         // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`           // 群ID
 		Type            string `json:"Type"`              // 群组类型
 		RequestorUserId string `json:"Requestor_Account"` // 申请者
@@ -225,6 +234,7 @@ type (
 	// BeforeInviteJoinGroup 拉人入群之前回调
 	BeforeInviteJoinGroup struct {
 		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64  `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`          // 群ID
 		Type            string `json:"Type"`             // 群组类型
 		OperatorUserId  string `json:"Operator_Account"` // 操作者
@@ -242,6 +252,7 @@ type (
 	// AfterNewMemberJoinGroup 新成员入群之后回调
 	AfterNewMemberJoinGroup struct {
 		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64  `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`          // 群ID
 		Type            string `json:"Type"`             // 群组类型
 		JoinType        string `json:"JoinType"`         // 入群方式：Apply（申请入群）；Invited（邀请入群）
@@ -254,6 +265,7 @@ type (
 	// AfterMemberExitGroup 群成员离开之后回调
 	AfterMemberExitGroup struct {
 		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64  `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`          // 群ID
 		Type            string `json:"Type"`             // 群组类型
 		ExitType        string `json:"ExitType"`         // 成员离开方式：Kicked-被踢；Quit-主动退群
@@ -266,6 +278,7 @@ type (
 	// BeforeGroupMessageSend 群内发言之前回调
 	BeforeGroupMessageSend struct {
 		CallbackCommand string           `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64            `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string           `json:"GroupId"`          // 群ID
 		Type            string           `json:"Type"`             // 群组类型
 		FromUserId      string           `json:"From_Account"`     // 发送者
@@ -273,17 +286,20 @@ type (
 		OnlineOnlyFlag  int              `json:"OnlineOnlyFlag"`   // 在线消息，为1，否则为0；直播群忽略此属性，为默认值0。
 		MsgRandom       int              `json:"Random"`           // 随机数
 		MsgBody         []*types.MsgBody `json:"MsgBody"`          // 消息体
+		CloudCustomData string           `json:"CloudCustomData"`  // 消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 	}
 
 	// BeforeGroupMessageSendResp 群内发言之前回调应答
 	BeforeGroupMessageSendResp struct {
 		BaseResp
-		MsgBody []*types.MsgBody `json:"MsgBody,omitempty"` // （选填）App 修改之后的消息，如果没有，则默认使用用户发送的消息
+		MsgBody         []*types.MsgBody `json:"MsgBody,omitempty"`         // （选填）App 修改之后的消息，如果没有，则默认使用用户发送的消息
+		CloudCustomData string           `json:"CloudCustomData,omitempty"` // （选填）经过 App 修改之后的消息自定义数据，如果没有，则默认使用用户发送的消息自定义数据
 	}
 
 	// AfterGroupMessageSend 群内发言之后回调
 	AfterGroupMessageSend struct {
 		CallbackCommand string           `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64            `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string           `json:"GroupId"`          // 群ID
 		Type            string           `json:"Type"`             // 群组类型
 		FromUserId      string           `json:"From_Account"`     // 发送者
@@ -293,17 +309,20 @@ type (
 		MsgRandom       int              `json:"Random"`           // 随机数
 		MsgTime         int64            `json:"MsgTime"`          // 消息的时间
 		MsgBody         []*types.MsgBody `json:"MsgBody"`          // 消息体
+		CloudCustomData string           `json:"CloudCustomData"`  // 消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 	}
 
 	// AfterGroupFull 群组满员之后回调
 	AfterGroupFull struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		EventTime       int64  `json:"EventTime"`       // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`         // 群ID
 	}
 
 	// AfterGroupDestroyed 群组解散之后回调
 	AfterGroupDestroyed struct {
 		CallbackCommand string `json:"CallbackCommand"` // 回调命令
+		EventTime       int64  `json:"EventTime"`       // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`         // 群ID
 		Type            string `json:"Type"`            // 群组类型
 		Name            string `json:"Name"`            // 群组名称
@@ -316,9 +335,25 @@ type (
 	// AfterGroupInfoChanged 群组资料修改之后回调
 	AfterGroupInfoChanged struct {
 		CallbackCommand string `json:"CallbackCommand"`  // 回调命令
+		EventTime       int64  `json:"EventTime"`        // 触发本次回调的时间戳，单位为毫秒
 		GroupId         string `json:"GroupId"`          // 群ID
 		Type            string `json:"Type"`             // 群组类型
 		Notification    string `json:"Notification"`     // 修改后的群公告
 		OperatorUserId  string `json:"Operator_Account"` // 请求的发起者
 	}
+
+	MsgBody            = types.MsgBody
+	ImageInfo          = types.ImageInfo
+	MsgTextContent     = types.MsgTextContent
+	MsgFaceContent     = types.MsgFaceContent
+	MsgFileContent     = types.MsgFileContent
+	MsgImageContent    = types.MsgImageContent
+	MsgSoundContent    = types.MsgSoundContent
+	MsgVideoContent    = types.MsgVideoContent
+	MsgCustomContent   = types.MsgCustomContent
+	MsgLocationContent = types.MsgLocationContent
 )
+
+func (r *BaseResp) base() *BaseResp {
+	return r
+}
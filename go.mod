@@ -1,5 +1,3 @@
 module github.com/dobyte/tencent-im
 
 go 1.16
-
-require github.com/dobyte/http v0.0.2
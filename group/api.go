@@ -8,7 +8,10 @@
 package group
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dobyte/tencent-im/internal/conv"
 	"github.com/dobyte/tencent-im/internal/core"
@@ -42,8 +45,23 @@ const (
 	commandDeleteGroupMsgBySender      = "delete_group_msg_by_sender"
 	commandGetGroupSimpleMsg           = "group_msg_get_simple"
 	commandGetOnlineMemberNum          = "get_online_member_num"
-
-	batchGetGroupsLimit = 50 // 批量获取群组限制
+	commandGetGroupAttr                = "get_group_attr"
+	commandModifyGroupAttr             = "modify_group_attr"
+	commandDeleteGroupAttr             = "delete_group_attr"
+	commandClearGroupAttr              = "clear_group_attr"
+	commandGetGroupCounter             = "get_group_counter"
+	commandUpdateGroupCounter          = "update_group_counter"
+
+	batchGetGroupsLimit       = 50  // 批量获取群组限制
+	batchAddGroupMembersLimit = 300 // 批量添加群成员限制
+	batchRevokeMessagesLimit  = 10  // 批量撤回群消息限制
+	batchImportMessagesLimit  = 20  // 批量导入群消息限制
+	batchGetRolesInGroupLimit = 500 // 批量查询群成员身份限制
+
+	maxGroupAttrNum       = 16        // 群自定义属性的最大数量
+	maxGroupAttrKeyLen    = 32        // 群自定义属性key的最大长度
+	maxGroupAttrValueLen  = 4 * 1024  // 群自定义属性value的最大长度
+	maxGroupAttrTotalSize = 16 * 1024 // 群自定义属性的最大总长度
 )
 
 type API interface {
@@ -65,6 +83,15 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1614
 	PullGroups(arg *PullGroupsArg, fn func(ret *FetchGroupsRet)) (err error)
 
+	// SearchGroups 搜索群组
+	// 本方法由“拉取App中的所有群组（FetchGroups）”拓展而来
+	// 按群名称关键字、群主、群类型和群自定义数据在服务端拉取的群组中逐页筛选，单次调用只扫描 Limit 个群组，匹配结果可能少于 Limit。
+	// 需要继续搜索时将返回的 Next 作为下一次调用的参数。
+	// 筛选在客户端进行，并非服务端搜索，查找全部匹配的群组需要逐页拉取App中的所有群组，耗时与群组总数成正比。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1614
+	SearchGroups(arg *SearchGroupsArg) (ret *SearchGroupsRet, err error)
+
 	// CreateGroup 创建群组
 	// App 管理员可以通过该接口创建群组。
 	// 点击查看详细文档:
@@ -79,12 +106,14 @@ type API interface {
 
 	// GetGroups 获取多个群详细资料
 	// App 管理员可以根据群组 ID 获取群组的详细信息。
+	// 不存在或无权访问的群组同样会返回，可通过 Group.IsValid 和 Group.GetError 获取其错误信息。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1616
 	GetGroups(groupIds []string, filter ...*Filter) (groups []*Group, err error)
 
 	// FetchMembers 拉取群成员详细资料
 	// App管理员可以根据群组ID获取群组成员的资料。
+	// 直播群（AVChatRoom）后台会忽略 offset 且最多只返回 1000 个成员，此时请勿依赖 HasMore 翻页。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1617
 	FetchMembers(groupId string, limit, offset int, filter ...*Filter) (ret *FetchMembersRet, err error)
@@ -98,18 +127,28 @@ type API interface {
 
 	// UpdateGroup 修改群基础资料
 	// App管理员可以通过该接口修改指定群组的基础信息。
+	// 仅会修改已设置的字段，未设置的字段保持不变，可用于单独修改群公告等部分资料。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1620
 	UpdateGroup(group *Group) (err error)
 
 	// AddMembers 增加群成员
 	// App管理员可以通过该接口向指定的群中添加新成员。
+	// 单次添加超过300个成员时会自动分批请求，silence 为 true 时静默加人，不会通知群内其他成员。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1621
 	AddMembers(groupId string, userIds []string, silence ...bool) (results []AddMembersResult, err error)
 
+	// AddMember 增加单个群成员
+	// 本方法由“增加群成员（AddMembers）”拓展而来
+	// 用户已经是群成员时返回 ErrAlreadyMember，重试时可视为成功。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1621
+	AddMember(groupId, userId string, silence ...bool) (err error)
+
 	// DeleteMembers 删除群成员
 	// App管理员可以通过该接口删除群成员。
+	// reasonAndSilence 依次为踢出原因（string，被踢用户可见）和是否静默删人（bool），后台不返回逐个成员的删除结果。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1622
 	DeleteMembers(groupId string, userIds []string, reasonAndSilence ...interface{}) (err error)
@@ -120,8 +159,23 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1623
 	UpdateMember(groupId string, member *Member) (err error)
 
+	// SetMemberRole 设置群成员身份
+	// 本方法由“修改群成员资料（UpdateMember）”拓展而来
+	// role 仅支持 MemberRoleAdmin（设为管理员）和 MemberRoleMember（取消管理员）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1623
+	SetMemberRole(groupId, userId, role string) (err error)
+
+	// MuteMember 禁言群成员
+	// 本方法由“修改群成员资料（UpdateMember）”拓展而来
+	// duration 为禁言时长，不足1秒按1秒计算，为0时表示取消禁言。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1623
+	MuteMember(groupId, userId string, duration time.Duration) (err error)
+
 	// DestroyGroup 解散群组
 	// App管理员通过该接口解散群。
+	// 群组不存在或已被解散时返回的错误满足 errors.Is(err, ErrGroupNotFound)，幂等清理时可视为成功。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1624
 	DestroyGroup(groupId string) (err error)
@@ -141,6 +195,7 @@ type API interface {
 
 	// GetRolesInGroup 查询用户在群组中的身份
 	// App管理员可以通过该接口获取一批用户在群内的身份，即“成员角色”。
+	// 返回的身份为 MemberRoleOwner、MemberRoleAdmin、MemberRoleMember 或 MemberRoleNotMember，单次查询超过500个用户时会自动分批请求。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1626
 	GetRolesInGroup(groupId string, userIds []string) (memberRoles map[string]string, err error)
@@ -165,6 +220,13 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/2925
 	GetShuttedUpMembers(groupId string) (shuttedUps map[string]int64, err error)
 
+	// GetMutedMembers 获取禁言中的群成员
+	// 本方法由“获取被禁言群成员列表（GetShuttedUpMembers）”拓展而来
+	// 仅返回禁言尚未到期的成员，并计算相对当前时间的剩余禁言时长。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/2925
+	GetMutedMembers(groupId string) (members []MutedMember, err error)
+
 	// SendMessage 在群组中发送普通消息
 	// App管理员可以通过该接口在群组中发送普通消息。
 	// 点击查看详细文档:
@@ -181,18 +243,21 @@ type API interface {
 	// App 管理员可以通过该接口将群主身份转移给他人。
 	// 没有群主的群，App 管理员可以通过此接口指定他人作为群主。
 	// 新群主必须为群内成员。
+	// 后台拒绝请求时会额外查询一次新群主的身份，确认不是群成员后才返回 ErrNewOwnerNotMember，否则返回后台的原始错误。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1633
 	ChangeGroupOwner(groupId, userId string) (err error)
 
 	// RevokeMessage 撤回单条群消息
 	// 本方法由“撤回多条群消息（RevokeMessages）”拓展而来
+	// 消息超出可撤回时限时返回的错误满足 errors.Is(err, ErrRevokeMessageExpired)。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/12341
 	RevokeMessage(groupId string, msgSeq int) (err error)
 
 	// RevokeMessages 撤回多条群消息
 	// App 管理员通过该接口撤回指定群组的消息，消息需要在漫游有效期以内。
+	// 单次撤回超过10条消息时会自动分批请求；消息超出可撤回时限时，对应的结果码为 ErrRevokeMessageExpired 的错误码。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/12341
 	RevokeMessages(groupId string, msgSeq ...int) (results map[int]int, err error)
@@ -206,6 +271,7 @@ type API interface {
 	// ImportMessages 导入群消息
 	// 该 API 接口的作用是导入群组的消息，不会触发回调、不会下发通知。
 	// 当 App 需要从其他即时通信系统迁移到即时通信 IM 时，使用该协议导入存量群消息数据。
+	// 消息按传入顺序分批导入（每批最多20条），所有消息的发送时间均不能晚于当前时间。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1635
 	ImportMessages(groupId string, messages ...*Message) (results []ImportMessagesResult, err error)
@@ -249,9 +315,49 @@ type API interface {
 
 	// GetOnlineMemberNum 获取直播群在线人数
 	// App 管理员可以根据群组 ID 获取直播群在线人数。
+	// 仅支持直播群（AVChatRoom），对其他类型的群组调用时返回 ErrNotLiveRoom。
+	// 后台拒绝请求时会额外查询一次群组类型，确认不是直播群后才返回 ErrNotLiveRoom，否则返回后台的原始错误。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/49180
 	GetOnlineMemberNum(groupId string) (num int, err error)
+
+	// GetAttributes 获取群自定义属性
+	// App 管理员可以通过该接口获取群自定义属性，仅适用于直播群（AVChatRoom）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/67012
+	GetAttributes(groupId string) (attrs map[string]string, err error)
+
+	// SetAttributes 修改群自定义属性
+	// App 管理员可以通过该接口修改群自定义属性，仅适用于直播群（AVChatRoom）。
+	// 已存在的属性会被覆盖，不存在的属性会被新增。
+	// 每个群最多16个属性，key 不超过32字节，value 不超过4KB，所有属性总长度不超过16KB，超出限制时不会发起请求。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/67010
+	SetAttributes(groupId string, attrs map[string]string) (err error)
+
+	// DeleteAttributes 删除群自定义属性
+	// App 管理员可以通过该接口删除指定的群自定义属性，仅适用于直播群（AVChatRoom）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1520
+	DeleteAttributes(groupId string, keys ...string) (err error)
+
+	// ClearAttributes 清空群自定义属性
+	// App 管理员可以通过该接口清空群的所有自定义属性，仅适用于直播群（AVChatRoom）。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/67009
+	ClearAttributes(groupId string) (err error)
+
+	// GetCounters 获取群计数器
+	// App 管理员可以通过该接口获取群计数器，未指定 keys 时获取该群的全部计数器。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1520
+	GetCounters(groupId string, keys ...string) (counters map[string]int64, err error)
+
+	// UpdateCounters 更新群计数器
+	// App 管理员可以通过该接口设置、增加或减少群计数器的值，增减操作由后台原子执行，并返回更新后的计数器。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1520
+	UpdateCounters(groupId string, counters map[string]int64, mode CounterMode) (ret map[string]int64, err error)
 }
 
 type api struct {
@@ -329,9 +435,18 @@ func (a *api) FetchGroups(limit int, next int, groupTypeAndFilter ...interface{}
 	ret = &FetchGroupsRet{Next: resp.Next, Total: resp.Total, HasMore: resp.HasMore}
 
 	if len(resp.List) > 0 {
-		if ret.List, err = a.GetGroups(resp.List, filter); err != nil {
+		var groups []*Group
+		if groups, err = a.GetGroups(resp.List, filter); err != nil {
 			return
 		}
+
+		// 拉取期间被解散或无权访问的群组不返回
+		ret.List = make([]*Group, 0, len(groups))
+		for _, group := range groups {
+			if group.IsValid() {
+				ret.List = append(ret.List, group)
+			}
+		}
 	}
 
 	return
@@ -367,6 +482,80 @@ func (a *api) PullGroups(arg *PullGroupsArg, fn func(ret *FetchGroupsRet)) (err
 	return
 }
 
+// SearchGroups 搜索群组
+// 本方法由“拉取App中的所有群组（FetchGroups）”拓展而来
+// 按群名称关键字、群主、群类型和群自定义数据在服务端拉取的群组中逐页筛选，单次调用只扫描 Limit 个群组，匹配结果可能少于 Limit。
+// 需要继续搜索时将返回的 Next 作为下一次调用的参数。
+// 筛选在客户端进行，并非服务端搜索，查找全部匹配的群组需要逐页拉取App中的所有群组，耗时与群组总数成正比。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1614
+func (a *api) SearchGroups(arg *SearchGroupsArg) (ret *SearchGroupsRet, err error) {
+	var (
+		filter *Filter
+		resp   *FetchGroupsRet
+	)
+
+	if arg == nil {
+		err = core.NewError(enum.InvalidParamsCode, "the search argument is not set")
+		return
+	}
+
+	if len(arg.CustomData) > 0 {
+		filter = &Filter{}
+		for _, field := range []BaseInfoField{BaseFieldGroupId, BaseFieldType, BaseFieldName, BaseFieldIntroduction, BaseFieldNotification, BaseFieldAvatar, BaseFieldOwner, BaseFieldMemberNum, BaseFieldMaxMemberNum} {
+			filter.AddBaseInfoFilter(field)
+		}
+
+		for key := range arg.CustomData {
+			filter.AddGroupCustomDataFilter(key)
+		}
+	}
+
+	limit := arg.Limit
+	if limit <= 0 {
+		limit = batchGetGroupsLimit
+	}
+
+	if resp, err = a.FetchGroups(limit, arg.Next, arg.Type, filter); err != nil {
+		return
+	}
+
+	ret = &SearchGroupsRet{Next: resp.Next, HasMore: resp.HasMore, List: make([]*Group, 0, len(resp.List))}
+
+	for _, group := range resp.List {
+		if !group.IsValid() {
+			continue
+		}
+
+		if arg.Keyword != "" && !strings.Contains(group.name, arg.Keyword) {
+			continue
+		}
+
+		if arg.Owner != "" && group.owner != arg.Owner {
+			continue
+		}
+
+		if !matchCustomData(group, arg.CustomData) {
+			continue
+		}
+
+		ret.List = append(ret.List, group)
+	}
+
+	return
+}
+
+// 检测群自定义数据是否全部匹配
+func matchCustomData(group *Group, data map[string]string) bool {
+	for key, expect := range data {
+		if val, ok := group.GetCustomData(key); !ok || conv.String(val) != expect {
+			return false
+		}
+	}
+
+	return true
+}
+
 // CreateGroup 创建群组
 // App管理员可以通过该接口创建群组。
 // 点击查看详细文档:
@@ -429,8 +618,8 @@ func (a *api) CreateGroup(group *Group) (groupId string, err error) {
 
 	if err = a.client.Post(serviceGroup, commandCreateGroup, req, resp); err != nil {
 		return
-	} else {
-		groupId = resp.GroupId
+	} else if groupId = resp.GroupId; groupId == "" {
+		groupId = group.id
 	}
 
 	return
@@ -460,6 +649,7 @@ func (a *api) GetGroup(groupId string, filter ...*Filter) (group *Group, err err
 
 // GetGroups 获取多个群详细资料
 // App 管理员可以根据群组 ID 获取群组的详细信息。
+// 不存在或无权访问的群组同样会返回，可通过 Group.IsValid 和 Group.GetError 获取其错误信息。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1616
 func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group, err error) {
@@ -491,11 +681,12 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 
 	groups = make([]*Group, 0, len(resp.GroupInfos))
 	for _, item := range resp.GroupInfos {
-		group := NewGroup()
+		group := NewGroup(item.GroupId)
 		group.setError(item.ErrorCode, item.ErrorInfo)
 		if group.err == nil {
-			group.id = item.GroupId
 			group.name = item.Name
+			group.introduction = item.Introduction
+			group.notification = item.Notification
 			group.groupType = item.Type
 			group.owner = item.OwnerUserId
 			group.avatar = item.FaceUrl
@@ -535,9 +726,9 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 					group.AddMembers(member)
 				}
 			}
-
-			groups = append(groups, group)
 		}
+
+		groups = append(groups, group)
 	}
 
 	return
@@ -545,6 +736,7 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 
 // FetchMembers 拉取群成员详细资料
 // App管理员可以根据群组ID获取群组成员的资料。
+// 直播群（AVChatRoom）后台会忽略 offset 且最多只返回 1000 个成员，此时请勿依赖 HasMore 翻页。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1617
 func (a *api) FetchMembers(groupId string, limit, offset int, filters ...*Filter) (ret *FetchMembersRet, err error) {
@@ -567,7 +759,8 @@ func (a *api) FetchMembers(groupId string, limit, offset int, filters ...*Filter
 	ret = &FetchMembersRet{}
 	ret.Total = resp.MemberNum
 	ret.List = make([]*Member, 0, len(resp.MemberList))
-	ret.HasMore = resp.MemberNum > limit+offset
+	ret.NextOffset = offset + len(resp.MemberList)
+	ret.HasMore = limit > 0 && len(resp.MemberList) > 0 && resp.MemberNum > ret.NextOffset
 
 	for _, m := range resp.MemberList {
 		member := &Member{
@@ -612,7 +805,7 @@ func (a *api) PullMembers(arg *PullMembersArg, fn func(ret *FetchMembersRet)) (e
 		fn(ret)
 
 		if ret.HasMore {
-			offset += arg.Limit
+			offset = ret.NextOffset
 		}
 	}
 
@@ -621,6 +814,7 @@ func (a *api) PullMembers(arg *PullMembersArg, fn func(ret *FetchMembersRet)) (e
 
 // UpdateGroup 修改群基础资料
 // App管理员可以通过该接口修改指定群组的基础信息。
+// 仅会修改已设置的字段，未设置的字段保持不变，可用于单独修改群公告等部分资料。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1620
 func (a *api) UpdateGroup(group *Group) (err error) {
@@ -657,37 +851,104 @@ func (a *api) UpdateGroup(group *Group) (err error) {
 
 // AddMembers 增加群成员
 // App管理员可以通过该接口向指定的群中添加新成员。
+// 单次添加超过300个成员时会自动分批请求，silence 为 true 时静默加人，不会通知群内其他成员。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1621
 func (a *api) AddMembers(groupId string, userIds []string, silence ...bool) (results []AddMembersResult, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the members is not set")
+		return
+	}
+
 	req := &addMembersReq{}
 	req.GroupId = groupId
-	req.MemberList = make([]addMemberItem, 0, len(userIds))
-	for _, userId := range userIds {
-		req.MemberList = append(req.MemberList, addMemberItem{
-			UserId: userId,
-		})
-	}
 	if len(silence) > 0 && silence[0] {
 		req.Silence = 1
 	}
 
-	resp := &addMembersResp{}
+	results = make([]AddMembersResult, 0, len(userIds))
+	for i := 0; i < len(userIds); i += batchAddGroupMembersLimit {
+		end := i + batchAddGroupMembersLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+
+		req.MemberList = make([]addMemberItem, 0, end-i)
+		for _, userId := range userIds[i:end] {
+			req.MemberList = append(req.MemberList, addMemberItem{
+				UserId: userId,
+			})
+		}
+
+		resp := &addMembersResp{}
+
+		if err = a.client.Post(serviceGroup, commandAddGroupMembers, req, resp); err != nil {
+			return
+		}
+
+		results = append(results, resp.MemberList...)
+	}
+
+	return
+}
+
+// AddMember 增加单个群成员
+// 本方法由“增加群成员（AddMembers）”拓展而来
+// 用户已经是群成员时返回 ErrAlreadyMember，重试时可视为成功。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1621
+func (a *api) AddMember(groupId, userId string, silence ...bool) (err error) {
+	if userId == "" {
+		err = errNotSetUserId
+		return
+	}
+
+	var results []AddMembersResult
 
-	if err = a.client.Post(serviceGroup, commandAddGroupMembers, req, resp); err != nil {
+	if results, err = a.AddMembers(groupId, []string{userId}, silence...); err != nil {
 		return
 	}
 
-	results = resp.MemberList
+	for _, result := range results {
+		if result.UserId != userId {
+			continue
+		}
+
+		switch result.Result {
+		case AddMemberResultSuccess, AddMemberResultWaiting:
+			return
+		case AddMemberResultAlreadyMember:
+			err = ErrAlreadyMember
+			return
+		}
+	}
+
+	err = errAddMemberFailed
 
 	return
 }
 
 // DeleteMembers 删除群成员
 // App管理员可以通过该接口删除群成员。
+// reasonAndSilence 依次为踢出原因（string，被踢用户可见）和是否静默删人（bool），后台不返回逐个成员的删除结果。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1622
 func (a *api) DeleteMembers(groupId string, userIds []string, reasonAndSilence ...interface{}) (err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the members is not set")
+		return
+	}
+
 	req := &deleteMembersReq{}
 	req.GroupId = groupId
 	req.UserIds = userIds
@@ -750,11 +1011,56 @@ func (a *api) UpdateMember(groupId string, member *Member) (err error) {
 	return
 }
 
+// SetMemberRole 设置群成员身份
+// 本方法由“修改群成员资料（UpdateMember）”拓展而来
+// role 仅支持 MemberRoleAdmin（设为管理员）和 MemberRoleMember（取消管理员）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1623
+func (a *api) SetMemberRole(groupId, userId, role string) (err error) {
+	if role == "" {
+		err = errInvalidMemberRole
+		return
+	}
+
+	member := NewMember(userId)
+	member.SetRole(role)
+
+	return a.UpdateMember(groupId, member)
+}
+
+// MuteMember 禁言群成员
+// 本方法由“修改群成员资料（UpdateMember）”拓展而来
+// duration 为禁言时长，不足1秒按1秒计算，为0时表示取消禁言。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1623
+func (a *api) MuteMember(groupId, userId string, duration time.Duration) (err error) {
+	if duration < 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the mute duration cannot be negative")
+		return
+	}
+
+	shutUpTime := int64(duration / time.Second)
+	if duration%time.Second != 0 {
+		shutUpTime++
+	}
+
+	member := NewMember(userId)
+	member.SetShutUpUntil(shutUpTime)
+
+	return a.UpdateMember(groupId, member)
+}
+
 // DestroyGroup 解散群组
 // App管理员通过该接口解散群。
+// 群组不存在或已被解散时返回的错误满足 errors.Is(err, ErrGroupNotFound)，幂等清理时可视为成功。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1624
 func (a *api) DestroyGroup(groupId string) (err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
 	req := &destroyGroupReq{GroupId: groupId}
 
 	if err = a.client.Post(serviceGroup, commandDestroyGroup, req, &types.ActionBaseResp{}); err != nil {
@@ -769,6 +1075,11 @@ func (a *api) DestroyGroup(groupId string) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1625
 func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGroupsRet, err error) {
+	if arg.UserId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	req := &fetchMemberGroupsReq{UserId: arg.UserId, Limit: arg.Limit, Offset: arg.Offset, Type: arg.Type}
 
 	if arg.Filter != nil {
@@ -806,6 +1117,8 @@ func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGrou
 		group := NewGroup()
 		group.id = item.GroupId
 		group.name = item.Name
+		group.introduction = item.Introduction
+		group.notification = item.Notification
 		group.groupType = item.Type
 		group.owner = item.OwnerUserId
 		group.avatar = item.FaceUrl
@@ -833,6 +1146,7 @@ func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGrou
 				msgSeq:          item.MemberInfo.MsgSeq,
 				msgFlag:         MsgFlag(item.MemberInfo.MsgFlag),
 				lastSendMsgTime: item.MemberInfo.LastSendMsgTime,
+				shutUpUntilTime: item.MemberInfo.ShutUpUntil,
 			}
 
 			if item.MemberInfo.AppMemberDefinedData != nil && len(item.MemberInfo.AppMemberDefinedData) > 0 {
@@ -886,19 +1200,37 @@ func (a *api) PullMemberGroups(arg *PullMemberGroupsArg, fn func(ret *FetchMembe
 
 // GetRolesInGroup 查询用户在群组中的身份
 // App管理员可以通过该接口获取一批用户在群内的身份，即“成员角色”。
+// 返回的身份为 MemberRoleOwner、MemberRoleAdmin、MemberRoleMember 或 MemberRoleNotMember，单次查询超过500个用户时会自动分批请求。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1626
 func (a *api) GetRolesInGroup(groupId string, userIds []string) (roles map[string]string, err error) {
-	req := &getRolesInGroupReq{GroupId: groupId, UserIds: userIds}
-	resp := &getRolesInGroupResp{}
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
 
-	if err = a.client.Post(serviceGroup, commandGetRoleInGroup, req, resp); err != nil {
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
 		return
 	}
 
-	roles = make(map[string]string, len(resp.MemberRoleList))
-	for _, item := range resp.MemberRoleList {
-		roles[item.UserId] = item.Role
+	roles = make(map[string]string, len(userIds))
+	for i := 0; i < len(userIds); i += batchGetRolesInGroupLimit {
+		end := i + batchGetRolesInGroupLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+
+		req := &getRolesInGroupReq{GroupId: groupId, UserIds: userIds[i:end]}
+		resp := &getRolesInGroupResp{}
+
+		if err = a.client.Post(serviceGroup, commandGetRoleInGroup, req, resp); err != nil {
+			return
+		}
+
+		for _, item := range resp.MemberRoleList {
+			roles[item.UserId] = item.Role
+		}
 	}
 
 	return
@@ -952,11 +1284,46 @@ func (a *api) GetShuttedUpMembers(groupId string) (shuttedUps map[string]int64,
 	return
 }
 
+// GetMutedMembers 获取禁言中的群成员
+// 本方法由“获取被禁言群成员列表（GetShuttedUpMembers）”拓展而来
+// 仅返回禁言尚未到期的成员，并计算相对当前时间的剩余禁言时长。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/2925
+func (a *api) GetMutedMembers(groupId string) (members []MutedMember, err error) {
+	var shuttedUps map[string]int64
+
+	if shuttedUps, err = a.GetShuttedUpMembers(groupId); err != nil {
+		return
+	}
+
+	now := time.Now()
+	members = make([]MutedMember, 0, len(shuttedUps))
+	for userId, shuttedUntil := range shuttedUps {
+		until := time.Unix(shuttedUntil, 0)
+		if !until.After(now) {
+			continue
+		}
+
+		members = append(members, MutedMember{
+			UserId:      userId,
+			ShutUpUntil: until,
+			Remaining:   until.Sub(now),
+		})
+	}
+
+	return
+}
+
 // SendMessage 在群组中发送普通消息
 // App管理员可以通过该接口在群组中发送普通消息。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1629
 func (a *api) SendMessage(groupId string, message *Message) (ret *SendMessageRet, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
 	if err = message.checkSendError(); err != nil {
 		return
 	}
@@ -996,8 +1363,9 @@ func (a *api) SendMessage(groupId string, message *Message) (ret *SendMessageRet
 		return
 	} else {
 		ret = &SendMessageRet{
-			MsgSeq:  resp.MsgSeq,
-			MsgTime: resp.MsgTime,
+			MsgSeq:    resp.MsgSeq,
+			MsgTime:   resp.MsgTime,
+			MsgRandom: req.Random,
 		}
 	}
 
@@ -1022,12 +1390,30 @@ func (a *api) SendNotification(groupId, content string, userIds ...string) (err
 // App 管理员可以通过该接口将群主身份转移给他人。
 // 没有群主的群，App 管理员可以通过此接口指定他人作为群主。
 // 新群主必须为群内成员。
+// 后台拒绝请求时会额外查询一次新群主的身份，确认不是群成员后才返回 ErrNewOwnerNotMember，否则返回后台的原始错误。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1633
 func (a *api) ChangeGroupOwner(groupId, userId string) (err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the new owner's userid is not set")
+		return
+	}
+
 	req := &changeGroupOwnerReq{GroupId: groupId, OwnerUserId: userId}
 
 	if err = a.client.Post(serviceGroup, commandChangeGroupOwner, req, &types.ActionBaseResp{}); err != nil {
+		var e core.Error
+		if errors.As(err, &e) {
+			// 后台拒绝时确认新群主的身份，仅非群成员的错误转换为 ErrNewOwnerNotMember
+			if roles, _ := a.GetRolesInGroup(groupId, []string{userId}); roles[userId] == MemberRoleNotMember {
+				err = ErrNewOwnerNotMember
+			}
+		}
 		return
 	}
 
@@ -1036,6 +1422,7 @@ func (a *api) ChangeGroupOwner(groupId, userId string) (err error) {
 
 // RevokeMessage 撤回单条群消息
 // 本方法由“撤回多条群消息（RevokeMessages）”拓展而来
+// 消息超出可撤回时限时返回的错误满足 errors.Is(err, ErrRevokeMessageExpired)。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/12341
 func (a *api) RevokeMessage(groupId string, msgSeq int) (err error) {
@@ -1055,27 +1442,46 @@ func (a *api) RevokeMessage(groupId string, msgSeq int) (err error) {
 
 // RevokeMessages 撤回多条群消息
 // App 管理员通过该接口撤回指定群组的消息，消息需要在漫游有效期以内。
+// 单次撤回超过10条消息时会自动分批请求；消息超出可撤回时限时，对应的结果码为 ErrRevokeMessageExpired 的错误码。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/12341
 func (a *api) RevokeMessages(groupId string, msgSeq ...int) (results map[int]int, err error) {
-	req := revokeMessagesReq{}
-	req.GroupId = groupId
-	req.MsgSeqList = make([]msgSeqItem, 0, len(msgSeq))
-	for _, seq := range msgSeq {
-		req.MsgSeqList = append(req.MsgSeqList, msgSeqItem{
-			MsgSeq: seq,
-		})
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
 	}
 
-	resp := &revokeMessagesResp{}
-
-	if err = a.client.Post(serviceGroup, commandRecallGroupMsg, req, resp); err != nil {
+	if len(msgSeq) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the message's seq is not set")
 		return
 	}
 
-	results = make(map[int]int)
-	for _, item := range resp.Results {
-		results[item.MsgSeq] = item.RetCode
+	req := revokeMessagesReq{}
+	req.GroupId = groupId
+
+	results = make(map[int]int, len(msgSeq))
+	for i := 0; i < len(msgSeq); i += batchRevokeMessagesLimit {
+		end := i + batchRevokeMessagesLimit
+		if end > len(msgSeq) {
+			end = len(msgSeq)
+		}
+
+		req.MsgSeqList = make([]msgSeqItem, 0, end-i)
+		for _, seq := range msgSeq[i:end] {
+			req.MsgSeqList = append(req.MsgSeqList, msgSeqItem{
+				MsgSeq: seq,
+			})
+		}
+
+		resp := &revokeMessagesResp{}
+
+		if err = a.client.Post(serviceGroup, commandRecallGroupMsg, req, resp); err != nil {
+			return
+		}
+
+		for _, item := range resp.Results {
+			results[item.MsgSeq] = item.RetCode
+		}
 	}
 
 	return
@@ -1126,31 +1532,48 @@ func (a *api) ImportGroup(group *Group) (groupId string, err error) {
 // ImportMessages 导入群消息
 // 该 API 接口的作用是导入群组的消息，不会触发回调、不会下发通知。
 // 当 App 需要从其他即时通信系统迁移到即时通信 IM 时，使用该协议导入存量群消息数据。
+// 消息按传入顺序分批导入（每批最多20条），所有消息的发送时间均不能晚于当前时间。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1635
 func (a *api) ImportMessages(groupId string, messages ...*Message) (results []ImportMessagesResult, err error) {
-	req := &importMessagesReq{GroupId: groupId, Messages: make([]messageItem, 0, len(messages))}
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
 
 	for _, message := range messages {
 		if err = message.checkImportError(); err != nil {
 			return
 		}
-
-		req.Messages = append(req.Messages, messageItem{
-			FromUserId: message.GetSender(),
-			MsgBody:    message.GetBody(),
-			SendTime:   message.GetSendTime(),
-			Random:     message.GetRandom(),
-		})
 	}
 
-	resp := &importMessagesResp{}
+	req := &importMessagesReq{GroupId: groupId}
 
-	if err = a.client.Post(serviceGroup, commandImportGroupMsg, req, resp); err != nil {
-		return
-	}
+	results = make([]ImportMessagesResult, 0, len(messages))
+	for i := 0; i < len(messages); i += batchImportMessagesLimit {
+		end := i + batchImportMessagesLimit
+		if end > len(messages) {
+			end = len(messages)
+		}
 
-	results = resp.Results
+		req.Messages = make([]messageItem, 0, end-i)
+		for _, message := range messages[i:end] {
+			req.Messages = append(req.Messages, messageItem{
+				FromUserId: message.GetSender(),
+				MsgBody:    message.GetBody(),
+				SendTime:   message.GetSendTime(),
+				Random:     message.GetRandom(),
+			})
+		}
+
+		resp := &importMessagesResp{}
+
+		if err = a.client.Post(serviceGroup, commandImportGroupMsg, req, resp); err != nil {
+			return
+		}
+
+		results = append(results, resp.Results...)
+	}
 
 	return
 }
@@ -1218,6 +1641,11 @@ func (a *api) RevokeMemberMessages(groupId, userId string) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/2738
 func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *FetchMessagesRet, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
 	req := &fetchMessagesReq{GroupId: groupId, ReqMsgNumber: limit}
 
 	if len(msgSeq) > 0 {
@@ -1245,6 +1673,11 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		}
 	}
 
+	// 已拉取到第一条消息时无需继续拉取，否则下一次拉取会重新从最新的消息开始
+	if ret.NextSeq <= 0 {
+		ret.HasMore = false
+	}
+
 	ret.List = make([]*Message, 0, len(resp.RspMsgList))
 	for _, item := range resp.RspMsgList {
 		message := NewMessage()
@@ -1253,6 +1686,9 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		message.seq = item.MsgSeq
 		message.timestamp = item.MsgTimeStamp
 		message.status = MsgStatus(item.IsPlaceMsg)
+		if item.CloudCustomData != "" {
+			message.customData = item.CloudCustomData
+		}
 		switch item.MsgPriority {
 		case 1:
 			message.priority = MsgPriorityHigh
@@ -1263,6 +1699,14 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		case 4:
 			message.priority = MsgPriorityLowest
 		}
+
+		body := make([]*types.MsgBody, 0, len(item.MsgBody))
+		for i := range item.MsgBody {
+			body = append(body, &item.MsgBody[i])
+		}
+		message.SetBody(body...)
+
+		ret.List = append(ret.List, message)
 	}
 
 	return
@@ -1299,13 +1743,30 @@ func (a *api) PullMessages(groupId string, limit int, fn func(ret *FetchMessages
 
 // GetOnlineMemberNum 获取直播群在线人数
 // App 管理员可以根据群组 ID 获取直播群在线人数。
+// 仅支持直播群（AVChatRoom），对其他类型的群组调用时返回 ErrNotLiveRoom。
+// 后台拒绝请求时会额外查询一次群组类型，确认不是直播群后才返回 ErrNotLiveRoom，否则返回后台的原始错误。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/49180
 func (a *api) GetOnlineMemberNum(groupId string) (num int, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
 	req := &getOnlineMemberNumReq{GroupId: groupId}
 	resp := &getOnlineMemberNumResp{}
 
 	if err = a.client.Post(serviceGroup, commandGetOnlineMemberNum, req, resp); err != nil {
+		var e core.Error
+		if errors.As(err, &e) {
+			filter := &Filter{}
+			filter.AddBaseInfoFilter(BaseFieldType)
+
+			// 后台拒绝时确认群组类型，仅非直播群的错误转换为 ErrNotLiveRoom
+			if group, _ := a.GetGroup(groupId, filter); group != nil && group.GetGroupType() != TypeLiveRoom {
+				err = ErrNotLiveRoom
+			}
+		}
 		return
 	}
 
@@ -1313,3 +1774,202 @@ func (a *api) GetOnlineMemberNum(groupId string) (num int, err error) {
 
 	return
 }
+
+// GetAttributes 获取群自定义属性
+// App 管理员可以通过该接口获取群自定义属性，仅适用于直播群（AVChatRoom）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/67012
+func (a *api) GetAttributes(groupId string) (attrs map[string]string, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	req := &getAttributesReq{GroupId: groupId}
+	resp := &getAttributesResp{}
+
+	if err = a.client.Post(serviceGroup, commandGetGroupAttr, req, resp); err != nil {
+		return
+	}
+
+	attrs = make(map[string]string, len(resp.Attrs))
+	for _, item := range resp.Attrs {
+		attrs[item.Key] = item.Value
+	}
+
+	return
+}
+
+// SetAttributes 修改群自定义属性
+// App 管理员可以通过该接口修改群自定义属性，仅适用于直播群（AVChatRoom）。
+// 已存在的属性会被覆盖，不存在的属性会被新增。
+// 每个群最多16个属性，key 不超过32字节，value 不超过4KB，所有属性总长度不超过16KB，超出限制时不会发起请求。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/67010
+func (a *api) SetAttributes(groupId string, attrs map[string]string) (err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	if err = checkAttributesArgError(attrs); err != nil {
+		return
+	}
+
+	req := &modifyAttributesReq{GroupId: groupId, Attrs: make([]attrItem, 0, len(attrs))}
+	for key, val := range attrs {
+		req.Attrs = append(req.Attrs, attrItem{Key: key, Value: val})
+	}
+
+	if err = a.client.Post(serviceGroup, commandModifyGroupAttr, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// DeleteAttributes 删除群自定义属性
+// App 管理员可以通过该接口删除指定的群自定义属性，仅适用于直播群（AVChatRoom）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1520
+func (a *api) DeleteAttributes(groupId string, keys ...string) (err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	if len(keys) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the group's attribute keys is not set")
+		return
+	}
+
+	req := &deleteAttributesReq{GroupId: groupId, Attrs: make([]attrItem, 0, len(keys))}
+	for _, key := range keys {
+		req.Attrs = append(req.Attrs, attrItem{Key: key})
+	}
+
+	if err = a.client.Post(serviceGroup, commandDeleteGroupAttr, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// ClearAttributes 清空群自定义属性
+// App 管理员可以通过该接口清空群的所有自定义属性，仅适用于直播群（AVChatRoom）。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/67009
+func (a *api) ClearAttributes(groupId string) (err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	req := &clearAttributesReq{GroupId: groupId}
+
+	if err = a.client.Post(serviceGroup, commandClearGroupAttr, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetCounters 获取群计数器
+// App 管理员可以通过该接口获取群计数器，未指定 keys 时获取该群的全部计数器。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1520
+func (a *api) GetCounters(groupId string, keys ...string) (counters map[string]int64, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	req := &getCountersReq{GroupId: groupId, Keys: keys}
+	resp := &getCountersResp{}
+
+	if err = a.client.Post(serviceGroup, commandGetGroupCounter, req, resp); err != nil {
+		return
+	}
+
+	counters = make(map[string]int64, len(resp.Counters))
+	for _, item := range resp.Counters {
+		counters[item.Key] = item.Value
+	}
+
+	return
+}
+
+// UpdateCounters 更新群计数器
+// App 管理员可以通过该接口设置、增加或减少群计数器的值，增减操作由后台原子执行，并返回更新后的计数器。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1520
+func (a *api) UpdateCounters(groupId string, counters map[string]int64, mode CounterMode) (ret map[string]int64, err error) {
+	if groupId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
+		return
+	}
+
+	if len(counters) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the group's counters is not set")
+		return
+	}
+
+	switch mode {
+	case CounterModeSet, CounterModeIncrease, CounterModeDecrease:
+	default:
+		err = core.NewError(enum.InvalidParamsCode, "invalid group's counter mode")
+		return
+	}
+
+	req := &updateCountersReq{GroupId: groupId, Mode: mode, Counters: make([]counterItem, 0, len(counters))}
+	for key, val := range counters {
+		if key == "" {
+			err = core.NewError(enum.InvalidParamsCode, "the group's counter key is not set")
+			return
+		}
+		req.Counters = append(req.Counters, counterItem{Key: key, Value: val})
+	}
+
+	resp := &updateCountersResp{}
+
+	if err = a.client.Post(serviceGroup, commandUpdateGroupCounter, req, resp); err != nil {
+		return
+	}
+
+	ret = make(map[string]int64, len(resp.Counters))
+	for _, item := range resp.Counters {
+		ret[item.Key] = item.Value
+	}
+
+	return
+}
+
+// 检测群自定义属性参数错误
+func checkAttributesArgError(attrs map[string]string) error {
+	if len(attrs) == 0 {
+		return core.NewError(enum.InvalidParamsCode, "the group's attributes is not set")
+	}
+
+	if len(attrs) > maxGroupAttrNum {
+		return core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of group's attributes cannot exceed %d", maxGroupAttrNum))
+	}
+
+	size := 0
+	for key, val := range attrs {
+		if key == "" || len(key) > maxGroupAttrKeyLen {
+			return core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the length of group's attribute key must be between 1 and %d", maxGroupAttrKeyLen))
+		}
+
+		if len(val) > maxGroupAttrValueLen {
+			return core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the length of group's attribute value cannot exceed %d", maxGroupAttrValueLen))
+		}
+
+		size += len(key) + len(val)
+	}
+
+	if size > maxGroupAttrTotalSize {
+		return core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the total size of group's attributes cannot exceed %d", maxGroupAttrTotalSize))
+	}
+
+	return nil
+}
@@ -39,6 +39,7 @@ const (
 	MemberFieldMsgFlag         MemberInfoField = "MsgFlag"         // 消息接收选项
 	MemberFieldLastSendMsgTime MemberInfoField = "LastSendMsgTime" // 最后发送消息的时间
 	MemberFieldNameCard        MemberInfoField = "NameCard"        // 群名片
+	MemberFieldShutUpUntil     MemberInfoField = "ShutUpUntil"     // 禁言截至时间
 )
 
 type Filter struct {
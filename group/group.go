@@ -8,6 +8,7 @@
 package group
 
 import (
+	"errors"
 	"time"
 
 	"github.com/dobyte/tencent-im/internal/core"
@@ -15,12 +16,25 @@ import (
 )
 
 var (
+	errNotSetGroupId            = core.NewError(enum.InvalidParamsCode, "group id is not set")
 	errNotSetGroupType          = core.NewError(enum.InvalidParamsCode, "group type is not set")
 	errNotSetGroupName          = core.NewError(enum.InvalidParamsCode, "group name is not set")
 	errGroupNameTooLong         = core.NewError(enum.InvalidParamsCode, "group name is too long")
 	errInvalidGroupType         = core.NewError(enum.InvalidParamsCode, "invalid group type")
 	errGroupIntroductionTooLong = core.NewError(enum.InvalidParamsCode, "group introduction is too long")
 	errGroupNotificationTooLong = core.NewError(enum.InvalidParamsCode, "group notification is too long")
+	errInvalidApplyJoinOption   = core.NewError(enum.InvalidParamsCode, "invalid apply join option")
+)
+
+var (
+	// ErrGroupNotFound 群组不存在，或者曾经存在过，但是目前已经被解散
+	ErrGroupNotFound = core.NewError(10010, "the group does not exist or has been destroyed")
+
+	// ErrNewOwnerNotMember 新群主不是群成员
+	ErrNewOwnerNotMember = errors.New("the new owner is not a member of the group")
+
+	// ErrNotLiveRoom 群组不是直播群（AVChatRoom），不支持该操作
+	ErrNotLiveRoom = errors.New("the operation is only supported by live room (AVChatRoom) groups")
 )
 
 type (
@@ -32,13 +46,17 @@ type (
 
 	// ShutUpStatus 全员禁言状态
 	ShutUpStatus string
+
+	// CounterMode 群计数器修改方式
+	CounterMode string
 )
 
 const (
-	TypePublic   Type = "Public"     // Public（陌生人社交群）
-	TypePrivate  Type = "Private"    // Private（即 Work，好友工作群）
-	TypeChatRoom Type = "ChatRoom"   // ChatRoom（即 Meeting，会议群）
-	TypeLiveRoom Type = "AVChatRoom" // AVChatRoom（直播群）
+	TypePublic    Type = "Public"     // Public（陌生人社交群）
+	TypePrivate   Type = "Private"    // Private（即 Work，好友工作群）
+	TypeChatRoom  Type = "ChatRoom"   // ChatRoom（即 Meeting，会议群）
+	TypeLiveRoom  Type = "AVChatRoom" // AVChatRoom（直播群）
+	TypeCommunity Type = "Community"  // Community（社群）
 
 	ApplyJoinOptionFreeAccess     ApplyJoinOption = "FreeAccess"     // 自由加入
 	ApplyJoinOptionNeedPermission ApplyJoinOption = "NeedPermission" // 需要验证
@@ -46,6 +64,10 @@ const (
 
 	ShutUpStatusOn  ShutUpStatus = "On"  // 开启
 	ShutUpStatusOff ShutUpStatus = "Off" // 关闭
+
+	CounterModeSet      CounterMode = "Set"      // 设置为指定值
+	CounterModeIncrease CounterMode = "Increase" // 增加指定值
+	CounterModeDecrease CounterMode = "Decrease" // 减少指定值
 )
 
 type Group struct {
@@ -295,6 +317,10 @@ func (g *Group) checkCreateError() (err error) {
 		return
 	}
 
+	if err = g.checkApplyJoinOptionArgError(); err != nil {
+		return
+	}
+
 	return
 }
 
@@ -320,9 +346,16 @@ func (g *Group) checkImportError() (err error) {
 }
 
 // 检测更新错误
+// 未设置的字段不会被修改，因此群名称仅在设置时校验
 func (g *Group) checkUpdateError() (err error) {
-	if err = g.checkNameArgError(); err != nil {
-		return
+	if g.id == "" {
+		return errNotSetGroupId
+	}
+
+	if g.name != "" {
+		if err = g.checkNameArgError(); err != nil {
+			return
+		}
 	}
 
 	if err = g.checkIntroductionArgError(); err != nil {
@@ -333,6 +366,10 @@ func (g *Group) checkUpdateError() (err error) {
 		return
 	}
 
+	if err = g.checkApplyJoinOptionArgError(); err != nil {
+		return
+	}
+
 	return
 }
 
@@ -356,7 +393,7 @@ func (g *Group) checkTypeArgError() error {
 	}
 
 	switch Type(g.groupType) {
-	case TypePublic, TypePrivate, TypeChatRoom, TypeLiveRoom:
+	case TypePublic, TypePrivate, TypeChatRoom, TypeLiveRoom, TypeCommunity:
 	default:
 		return errInvalidGroupType
 	}
@@ -381,3 +418,14 @@ func (g *Group) checkNotificationArgError() error {
 
 	return nil
 }
+
+// 检测申请加群处理方式参数错误
+func (g *Group) checkApplyJoinOptionArgError() error {
+	switch ApplyJoinOption(g.applyJoinOption) {
+	case "", ApplyJoinOptionFreeAccess, ApplyJoinOptionNeedPermission, ApplyJoinOptionDisableApply:
+	default:
+		return errInvalidApplyJoinOption
+	}
+
+	return nil
+}
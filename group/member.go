@@ -12,17 +12,39 @@ import (
     "time"
 )
 
-var errNotSetUserId = errors.New("member's userid is not set")
+var (
+    errNotSetUserId      = errors.New("member's userid is not set")
+    errInvalidMemberRole = errors.New("member's role is invalid, only Admin or Member can be set")
+    errAddMemberFailed   = errors.New("failed to add the member to the group")
+)
+
+var (
+    // ErrAlreadyMember 用户已经是群成员
+    ErrAlreadyMember = errors.New("the user is already a member of the group")
+)
 
 type (
     // MsgFlag 消息接收选项
     MsgFlag string
+    
+    // AddMemberResult 添加群成员结果
+    AddMemberResult int
 )
 
 const (
     MsgFlagAcceptAndNotify MsgFlag = "AcceptAndNotify" // 接收并提示
     MsgFlagAcceptNotNotify MsgFlag = "AcceptNotNotify" // 接收不提示（不会触发 APNs 远程推送）
     MsgFlagDiscard         MsgFlag = "Discard"         // 屏蔽群消息（不会向客户端推送消息）
+    
+    AddMemberResultFailed        AddMemberResult = 0 // 添加失败
+    AddMemberResultSuccess       AddMemberResult = 1 // 添加成功
+    AddMemberResultAlreadyMember AddMemberResult = 2 // 已经是群成员
+    AddMemberResultWaiting       AddMemberResult = 3 // 等待被邀请者确认
+    
+    MemberRoleOwner     = "Owner"     // 群主
+    MemberRoleAdmin     = "Admin"     // 群管理员
+    MemberRoleMember    = "Member"    // 普通群成员
+    MemberRoleNotMember = "NotMember" // 非群成员
 )
 
 type Member struct {
@@ -34,6 +56,7 @@ type Member struct {
     msgFlag         MsgFlag                // 消息接收选项
     lastSendMsgTime int64                  // 最后发送消息的时间
     shutUpUntil     *int64                 // 需禁言时间，单位为秒，0表示取消禁言
+    shutUpUntilTime int64                  // 禁言截至时间
     unreadMsgNum    int                    // 未读入群成员的未读消息计数
     customData      map[string]interface{} // 自定义数据
 }
@@ -115,6 +138,16 @@ func (m *Member) GetShutUpUntil() int64 {
     }
 }
 
+// GetShutUpUntilTime 获取禁言截至时间，未被禁言时返回零值
+// 该时间仅在查询群成员信息时返回，不会在修改群成员资料时提交
+func (m *Member) GetShutUpUntilTime() time.Time {
+    if m.shutUpUntilTime <= 0 {
+        return time.Time{}
+    }
+    
+    return time.Unix(m.shutUpUntilTime, 0)
+}
+
 // SetUnreadMsgNum 设置成员的未读消息计数
 func (m *Member) SetUnreadMsgNum(unreadMsgNum int) {
     m.unreadMsgNum = unreadMsgNum
@@ -156,5 +189,11 @@ func (m *Member) checkError() (err error) {
         return errNotSetUserId
     }
     
+    switch m.role {
+    case "", MemberRoleAdmin, MemberRoleMember:
+    default:
+        return errInvalidMemberRole
+    }
+    
     return nil
 }
@@ -9,15 +9,23 @@ package group
 
 import (
 	"errors"
+	"time"
 	
+	"github.com/dobyte/tencent-im/internal/core"
 	"github.com/dobyte/tencent-im/internal/entity"
+	"github.com/dobyte/tencent-im/internal/enum"
 )
 
 var (
-	errNotSetSender   = errors.New("message's sender not set")
-	errNotSetSendTime = errors.New("message's send time not set")
+	errNotSetSender    = errors.New("message's sender not set")
+	errNotSetSendTime  = errors.New("message's send time not set")
+	errInvalidSendTime = errors.New("message's send time cannot be in the future")
+	errInvalidPriority = errors.New("message's priority is invalid")
 )
 
+// ErrRevokeMessageExpired 待撤回的群消息已超出可撤回时限
+var ErrRevokeMessageExpired = core.NewError(10031, "the group message to be revoked has expired")
+
 type (
 	// MsgOnlineOnlyFlag 只发送在线成员标识
 	MsgOnlineOnlyFlag int
@@ -45,6 +53,32 @@ const (
 	AtAllMembersFlag = "@all" // @所有成员的标识
 )
 
+const (
+	// 推送标识
+	PushFlagYes = enum.PushFlagYes // 正常推送
+	PushFlagNo  = enum.PushFlagNo  // 不离线推送
+	
+	// 华为推送通知消息分类
+	HuaWeiImportanceLow    = enum.HuaWeiImportanceLow    // LOW类消息
+	HuaWeiImportanceNormal = enum.HuaWeiImportanceNormal // NORMAL类消息
+	
+	// 华为推送为“打开应用内指定页面”的前提下透传参数行为
+	HuaweiIntentParamAction = enum.HuaweiIntentParamAction // 将透传内容Ext作为Action参数
+	HuaweiIntentParamIntent = enum.HuaweiIntentParamIntent // 将透传内容Ext作为Intent参数
+	
+	// VIVO手机推送消息分类
+	VivoClassificationOperation = enum.VivoClassificationOperation // 运营类消息
+	VivoClassificationSystem    = enum.VivoClassificationSystem    // 系统类消息
+	
+	// IOS徽章计数模式
+	BadgeModeNormal = enum.BadgeModeNormal // 本条消息需要计数
+	BadgeModeIgnore = enum.BadgeModeIgnore // 本条消息不需要计数
+	
+	// IOS10的推送扩展开关
+	MutableContentNormal = enum.MutableContentNormal // 关闭iOS10的推送扩展
+	MutableContentEnable = enum.MutableContentEnable // 开启iOS10的推送扩展
+)
+
 type Message struct {
 	entity.Message
 	priority         MsgPriority       // 消息的优先级
@@ -74,11 +108,13 @@ func (m *Message) GetPriority() MsgPriority {
 }
 
 // SetCustomData 设置自定义数据
+// 即消息的 CloudCustomData（云端保存，会发送到对端，拉取历史消息及回调时返回），非字符串数据将被转换为字符串
 func (m *Message) SetCustomData(data interface{}) {
 	m.customData = data
 }
 
 // GetCustomData 获取自定义数据
+// 拉取的历史消息中为字符串类型的 CloudCustomData
 func (m *Message) GetCustomData() interface{} {
 	return m.customData
 }
@@ -194,12 +230,27 @@ func (m *Message) GetTimestamp() int64 {
 	return m.timestamp
 }
 
+// GetSeq 获取消息序列号
+func (m *Message) GetSeq() int {
+	return m.seq
+}
+
 // 检测发送错误
 func (m *Message) checkSendError() (err error) {
 	if err = m.CheckBodyArgError(); err != nil {
 		return
 	}
 	
+	if err = m.CheckOfflinePushArgError(); err != nil {
+		return
+	}
+	
+	switch m.priority {
+	case "", MsgPriorityHigh, MsgPriorityNormal, MsgPriorityLow, MsgPriorityLowest:
+	default:
+		return errInvalidPriority
+	}
+	
 	return
 }
 
@@ -213,6 +264,10 @@ func (m *Message) checkImportError() (err error) {
 		return errNotSetSendTime
 	}
 	
+	if m.sendTime > time.Now().Unix() {
+		return errInvalidSendTime
+	}
+	
 	if err = m.CheckBodyArgError(); err != nil {
 		return
 	}
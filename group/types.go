@@ -7,7 +7,11 @@
 
 package group
 
-import "github.com/dobyte/tencent-im/internal/types"
+import (
+	"time"
+
+	"github.com/dobyte/tencent-im/internal/types"
+)
 
 type (
 	// 拉取App中的所有群组（请求）
@@ -48,6 +52,23 @@ type (
 		Filter *Filter // 过滤器
 	}
 
+	// SearchGroupsArg 搜索群组（参数）
+	SearchGroupsArg struct {
+		Keyword    string            // （选填）群名称关键字
+		Owner      string            // （选填）群主ID
+		Type       Type              // （选填）群组类型
+		CustomData map[string]string // （选填）群自定义数据，需全部相等才算匹配
+		Limit      int               // （选填）单次扫描的群组数量，不得超过50，不填时默认为50
+		Next       int               // （选填）扫描游标，第一次填0，以后填上一次返回的值
+	}
+
+	// SearchGroupsRet 搜索群组（返回）
+	SearchGroupsRet struct {
+		Next    int      // 下一次扫描的游标
+		HasMore bool     // 是否还有更多数据
+		List    []*Group // 本次扫描中匹配的群组列表
+	}
+
 	// 群ID
 	groupIdItem struct {
 		GroupId string `json:"GroupId"` // 群ID
@@ -163,9 +184,10 @@ type (
 
 	// FetchMembersRet 拉取群成员结果
 	FetchMembersRet struct {
-		Total   int       // 成员数量
-		HasMore bool      // 是否还有更多数据
-		List    []*Member // 成员列表
+		Total      int       // 成员数量
+		HasMore    bool      // 是否还有更多数据
+		NextOffset int       // 下一页的偏移量
+		List       []*Member // 成员列表
 	}
 
 	// PullMembersArg 续拉取群成员（参数）
@@ -207,15 +229,15 @@ type (
 
 	// AddMembersResult 添加群成员结果
 	AddMembersResult struct {
-		UserId string `json:"Member_Account"`
-		Result int    `json:"Result"`
+		UserId string          `json:"Member_Account"`
+		Result AddMemberResult `json:"Result"`
 	}
 
 	// 删除群成员（请求）
 	deleteMembersReq struct {
 		GroupId string   `json:"GroupId"`             // （必填）操作的群ID
-		Silence int      `json:"Silence"`             // （选填）是否静默删人
-		Reason  string   `json:"Reason"`              // （选填）踢出用户原因
+		Silence int      `json:"Silence,omitempty"`   // （选填）是否静默删人
+		Reason  string   `json:"Reason,omitempty"`    // （选填）踢出用户原因
 		UserIds []string `json:"MemberToDel_Account"` // （必填）待删除的群成员
 	}
 
@@ -226,7 +248,7 @@ type (
 		Role                 string           `json:"Role,omitempty"`                 // （选填）群内身份
 		NameCard             string           `json:"NameCard,omitempty"`             // （选填）群名片
 		MsgFlag              string           `json:"MsgFlag,omitempty"`              // （选填）消息接收选项
-		ShutUpUntil          *int64           `json:"ShutUpUntil,omitempty"`          // （选填）禁言截至时间
+		ShutUpUntil          *int64           `json:"ShutUpTime,omitempty"`           // （选填）需禁言时间，单位为秒，0表示取消禁言
 		AppMemberDefinedData []customDataItem `json:"AppMemberDefinedData,omitempty"` // （选填）群成员自定义数据
 	}
 
@@ -316,6 +338,13 @@ type (
 		ShuttedUntil int64  `json:"ShuttedUntil"`   // 禁言到的时间（使用 UTC 时间，即世界协调时间）
 	}
 
+	// MutedMember 禁言中的群成员
+	MutedMember struct {
+		UserId      string        // 用户ID
+		ShutUpUntil time.Time     // 禁言到的时间
+		Remaining   time.Duration // 剩余禁言时长
+	}
+
 	// 在群组中发送普通消息（请求）
 	sendMessageReq struct {
 		GroupId               string                 `json:"GroupId"`                         // （必填）向哪个群组发送消息
@@ -340,8 +369,9 @@ type (
 
 	// SendMessageRet 发送消息结果
 	SendMessageRet struct {
-		MsgSeq  int // 消息唯一标识，用于撤回。长度不超过50个字符
-		MsgTime int // 消息时间戳，UNIX 时间戳
+		MsgSeq    int    // 消息唯一标识，用于撤回。长度不超过50个字符
+		MsgTime   int    // 消息时间戳，UNIX 时间戳
+		MsgRandom uint32 // 消息随机数，后台用于同一秒内的消息去重
 	}
 
 	atInfo struct {
@@ -466,7 +496,7 @@ type (
 	// 拉取群历史消息（请求）
 	fetchMessagesReq struct {
 		GroupId      string `json:"GroupId"`                // （必填）要拉取历史消息的群组 ID
-		ReqMsgSeq    int    `json:"ReqMsgSeq"`              // （选填）拉取消息的最大seq
+		ReqMsgSeq    int    `json:"ReqMsgSeq,omitempty"`    // （选填）拉取消息的最大seq，不填时从最新的消息开始拉取
 		ReqMsgNumber int    `json:"ReqMsgNumber,omitempty"` // （必填）拉取的历史消息的条数，目前一次请求最多返回20条历史消息，所以这里最好小于等于20
 	}
 
@@ -486,13 +516,14 @@ type (
 	}
 
 	rspMsgItem struct {
-		FromUserId   string          `json:"From_Account"`
-		IsPlaceMsg   int             `json:"IsPlaceMsg"`
-		MsgBody      []types.MsgBody `json:"MsgBody"`
-		MsgPriority  int             `json:"MsgPriority"`
-		MsgRandom    uint32          `json:"MsgRandom"`
-		MsgSeq       int             `json:"MsgSeq"`
-		MsgTimeStamp int64           `json:"MsgTimeStamp"`
+		FromUserId      string          `json:"From_Account"`
+		IsPlaceMsg      int             `json:"IsPlaceMsg"`
+		MsgBody         []types.MsgBody `json:"MsgBody"`
+		MsgPriority     int             `json:"MsgPriority"`
+		MsgRandom       uint32          `json:"MsgRandom"`
+		MsgSeq          int             `json:"MsgSeq"`
+		MsgTimeStamp    int64           `json:"MsgTimeStamp"`
+		CloudCustomData string          `json:"CloudCustomData"`
 	}
 
 	// 获取直播群在线人数（请求）
@@ -505,4 +536,80 @@ type (
 		types.ActionBaseResp
 		OnlineMemberNum int `json:"OnlineMemberNum"` // 该群组的在线人数
 	}
+
+	// 获取群自定义属性（请求）
+	getAttributesReq struct {
+		GroupId string `json:"GroupId"` // （必填）操作的群ID
+	}
+
+	// 获取群自定义属性（响应）
+	getAttributesResp struct {
+		types.ActionBaseResp
+		Attrs []attrItem `json:"GroupAttrAry"` // 群自定义属性列表
+	}
+
+	// 修改群自定义属性（请求）
+	modifyAttributesReq struct {
+		GroupId string     `json:"GroupId"`   // （必填）操作的群ID
+		Attrs   []attrItem `json:"GroupAttr"` // （必填）群自定义属性列表
+	}
+
+	// 删除群自定义属性（请求）
+	deleteAttributesReq struct {
+		GroupId string     `json:"GroupId"`   // （必填）操作的群ID
+		Attrs   []attrItem `json:"GroupAttr"` // （必填）待删除的群自定义属性列表
+	}
+
+	// 清空群自定义属性（请求）
+	clearAttributesReq struct {
+		GroupId string `json:"GroupId"` // （必填）操作的群ID
+	}
+
+	// 群自定义属性
+	attrItem struct {
+		Key   string `json:"key"`             // 属性key
+		Value string `json:"value,omitempty"` // 属性value
+	}
+
+	// 获取群计数器（请求）
+	getCountersReq struct {
+		GroupId string   `json:"GroupId"`                    // （必填）操作的群ID
+		Keys    []string `json:"GroupCounterKeys,omitempty"` // （选填）待获取的群计数器key列表，不填时获取全部计数器
+	}
+
+	// 获取群计数器（响应）
+	getCountersResp struct {
+		types.ActionBaseResp
+		Counters []counterItem `json:"GroupCounter"` // 群计数器列表
+	}
+
+	// 更新群计数器（请求）
+	updateCountersReq struct {
+		GroupId  string        `json:"GroupId"`      // （必填）操作的群ID
+		Mode     CounterMode   `json:"Mode"`         // （必填）修改方式
+		Counters []counterItem `json:"GroupCounter"` // （必填）群计数器列表
+	}
+
+	// 更新群计数器（响应）
+	updateCountersResp struct {
+		types.ActionBaseResp
+		Counters []counterItem `json:"GroupCounter"` // 更新后的群计数器列表
+	}
+
+	// 群计数器
+	counterItem struct {
+		Key   string `json:"key"`   // 计数器key
+		Value int64  `json:"value"` // 计数器value
+	}
+
+	MsgBody            = types.MsgBody
+	ImageInfo          = types.ImageInfo
+	MsgTextContent     = types.MsgTextContent
+	MsgFaceContent     = types.MsgFaceContent
+	MsgFileContent     = types.MsgFileContent
+	MsgImageContent    = types.MsgImageContent
+	MsgSoundContent    = types.MsgSoundContent
+	MsgVideoContent    = types.MsgVideoContent
+	MsgCustomContent   = types.MsgCustomContent
+	MsgLocationContent = types.MsgLocationContent
 )
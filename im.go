@@ -8,6 +8,9 @@
 package im
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"sync"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/dobyte/tencent-im/callback"
 	"github.com/dobyte/tencent-im/group"
 	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/sign"
 	"github.com/dobyte/tencent-im/mute"
 	"github.com/dobyte/tencent-im/operation"
@@ -27,10 +31,43 @@ import (
 
 type Error = core.Error
 
+// Logger 请求日志接口，Debugf 输出的请求及响应内容中签名已脱敏
+type Logger = core.Logger
+
+// Observer 请求观测接口，可用于对接 Prometheus 等监控系统
+type Observer = core.Observer
+
+const (
+	RegionCN  = core.RegionCN  // 中国
+	RegionSG  = core.RegionSG  // 新加坡
+	RegionID  = core.RegionID  // 雅加达
+	RegionKR  = core.RegionKR  // 首尔
+	RegionGER = core.RegionGER // 法兰克福
+	RegionUSA = core.RegionUSA // 硅谷
+)
+
+const (
+	ErrorCodeInvalidParams     = enum.InvalidParamsCode   // 无效参数（SDK自定义）
+	ErrorCodeInvalidResponse   = enum.InvalidResponseCode // 无效响应（SDK自定义）
+	ErrorCodeUserSigInvalid    = 70001                    // UserSig 已过期或无效
+	ErrorCodeAccountNotFound   = 70107                    // 请求的用户帐号不存在
+	ErrorCodeAdminRequired     = 60010                    // 请求需要 App 管理员权限
+	ErrorCodeRequestLimited    = 60007                    // REST 接口调用频率超过限制
+	ErrorCodeRequestTimeout    = 60008                    // 服务请求超时
+	ErrorCodeAppRequestLimited = 60011                    // SDKAppID 请求频率超限
+	ErrorCodeGroupNotFound     = 10010                    // 群组不存在或已被解散
+)
+
+// IsErrorCode 判断错误是否为指定错误码的错误
+// 错误码可以是 ErrorCode 开头的常量，也可以是即时通信 IM 文档中的其它错误码
+func IsErrorCode(err error, code int) bool {
+	return core.IsErrorCode(err, code)
+}
+
 type (
 	IM interface {
-		// GetUserSig 获取UserSig签名
-		GetUserSig(userId string, expiration ...int) UserSig
+		// GetUserSig 获取UserSig签名，未指定过期时间时使用 Options.Expiration，均未设置时默认为3600秒
+		GetUserSig(userId string, expiration ...int) (UserSig, error)
 		// SNS 获取关系链管理接口
 		SNS() sns.API
 		// Mute 获取全局禁言管理接口
@@ -51,14 +88,26 @@ type (
 		RecentContact() recentcontact.API
 		// Callback 获取回调接口
 		Callback() callback.Callback
+		// WithContext 获取绑定了上下文的IM实例，通过其发起的请求将在上下文取消或超时后终止
+		WithContext(ctx context.Context) IM
+		// CallRaw 调用指定接口并返回原始的JSON响应，可用于调用SDK尚未支持的接口或字段
+		CallRaw(service, command string, body interface{}) (json.RawMessage, error)
 	}
 
 	Options struct {
-		AppId         int    // 应用SDKAppID，可在即时通信 IM 控制台 的应用卡片中获取。
-		AppSecret     string // 密钥信息，可在即时通信 IM 控制台 的应用详情页面中获取，具体操作请参见 获取密钥
-		UserId        string // 用户ID
-		Expiration    int    // UserSig过期时间
-		TIMServerHost string //tencent IM 服务器域名
+		AppId         int           // 应用SDKAppID，可在即时通信 IM 控制台 的应用卡片中获取。
+		AppSecret     string        // 密钥信息，可在即时通信 IM 控制台 的应用详情页面中获取，具体操作请参见 获取密钥
+		UserId        string        // 用户ID
+		Expiration    int           // UserSig过期时间（秒），默认为3600秒
+		RefreshGap    int           // UserSig提前刷新时间（秒），默认为60秒，且不超过过期时间的一半
+		MaxRetries    int           // 请求失败时的最大重试次数，默认不重试；频率超限时均会重试，网络异常、服务端5xx及超时等结果不确定的错误仅对查询及覆盖写入等幂等接口重试
+		RetryInterval time.Duration // 重试的基础间隔时间，按指数退避并附加随机抖动，默认为100毫秒
+		RateLimit     int           // 每秒最多发起的请求数，默认不限制
+		Region        string        // 应用所在地域（RegionCN、RegionSG 等），用于选择接口域名，默认为中国
+		TIMServerHost string        // 接口域名，如 https://console.tim.qq.com ，设置后将忽略 Region
+		HTTPClient    *http.Client  // 发起请求使用的HTTP客户端，可用于自定义超时、连接池及代理等配置
+		Logger        Logger        // 请求日志，记录每次请求的接口、请求ID、耗时及错误码，默认不输出
+		Observer      Observer      // 请求观测，每次请求结束后回调接口、状态码、错误码及耗时，可用于上报监控指标
 	}
 
 	UserSig struct {
@@ -118,19 +167,35 @@ func NewIM(opt *Options) IM {
 		AppSecret:     opt.AppSecret,
 		UserId:        opt.UserId,
 		Expiration:    opt.Expiration,
+		RefreshGap:    opt.RefreshGap,
+		MaxRetries:    opt.MaxRetries,
+		RetryInterval: opt.RetryInterval,
+		RateLimit:     opt.RateLimit,
+		Region:        opt.Region,
 		TIMServerHost: opt.TIMServerHost,
+		HTTPClient:    opt.HTTPClient,
+		Logger:        opt.Logger,
+		Observer:      opt.Observer,
 	})}
 }
 
 // GetUserSig 获取UserSig签名
-func (i *im) GetUserSig(userId string, expiration ...int) UserSig {
+// 未指定过期时间时使用 Options.Expiration，均未设置时默认为3600秒；指定的过期时间无效时返回错误
+func (i *im) GetUserSig(userId string, expiration ...int) (UserSig, error) {
 	if len(expiration) == 0 {
 		expiration = append(expiration, i.opt.Expiration)
+		if expiration[0] <= 0 {
+			expiration[0] = core.DefaultExpiration
+		}
+	}
+
+	userSig, err := sign.GenUserSig(i.opt.AppId, i.opt.AppSecret, userId, expiration[0])
+	if err != nil {
+		return UserSig{}, err
 	}
 
-	userSig, _ := sign.GenUserSig(i.opt.AppId, i.opt.AppSecret, userId, expiration[0])
-	expireAt := time.Now().Add(time.Duration(i.opt.Expiration) * time.Second).Unix()
-	return UserSig{UserSig: userSig, ExpireAt: expireAt}
+	expireAt := time.Now().Add(time.Duration(expiration[0]) * time.Second).Unix()
+	return UserSig{UserSig: userSig, ExpireAt: expireAt}, nil
 }
 
 // SNS 获取关系链管理接口ok
@@ -212,3 +277,21 @@ func (i *im) Callback() callback.Callback {
 	})
 	return i.callback.instance
 }
+
+// WithContext 获取绑定了上下文的IM实例
+// 返回的实例与原实例共享签名缓存、限流器及回调接口
+func (i *im) WithContext(ctx context.Context) IM {
+	c := &im{opt: i.opt, client: i.client.WithContext(ctx)}
+	c.callback.once.Do(func() {
+		c.callback.instance = i.Callback()
+	})
+	return c
+}
+
+// CallRaw 调用指定接口并返回原始的JSON响应
+// 请求将自动签名并以POST方式发送，如 CallRaw("openim", "sendmsg", body)
+// 需要取消或超时控制时请通过 WithContext 绑定上下文，如 WithContext(ctx).CallRaw("openim", "sendmsg", body)
+// 后台返回错误时同时返回原始响应及错误，错误可通过 IsErrorCode 判断
+func (i *im) CallRaw(service, command string, body interface{}) (json.RawMessage, error) {
+	return i.client.CallRaw(service, command, body)
+}
@@ -0,0 +1,94 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/27 11:31 上午
+ * @Desc: 批量执行
+ */
+
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const defaultConcurrency = 5
+
+// Errors 批量执行的错误列表，与执行项一一对应，执行成功的项为nil
+type Errors []error
+
+// Error 错误信息
+func (e Errors) Error() string {
+	var (
+		failed int
+		first  error
+	)
+
+	for _, err := range e {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d items failed, first error: %v", failed, len(e), first)
+}
+
+// Execute 以指定的并发数（不大于0时默认为5）执行 n 个执行项
+// 执行项全部成功时返回nil，否则返回 Errors；上下文取消或超时后，尚未执行的项的错误为上下文的错误
+func Execute(ctx context.Context, n, concurrency int, fn func(i int) error) error {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+		errs   = make(Errors, n)
+		sem    = make(chan struct{}, concurrency)
+	)
+
+	setErr := func(i int, err error) {
+		mu.Lock()
+		errs[i], failed = err, true
+		mu.Unlock()
+	}
+
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			setErr(i, err)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			setErr(i, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(i); err != nil {
+				setErr(i, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if !failed {
+		return nil
+	}
+
+	return errs
+}
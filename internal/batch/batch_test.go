@@ -0,0 +1,191 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteAllSucceed(t *testing.T) {
+	var calls int32
+
+	err := Execute(context.Background(), 10, 3, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if calls != 10 {
+		t.Fatalf("expected 10 calls, got %d", calls)
+	}
+}
+
+func TestExecuteZeroItems(t *testing.T) {
+	if err := Execute(context.Background(), 0, 3, func(i int) error {
+		t.Fatal("fn must not be called")
+		return nil
+	}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestExecuteErrorAlignment(t *testing.T) {
+	failures := map[int]error{
+		1: errors.New("item 1"),
+		4: errors.New("item 4"),
+	}
+
+	err := Execute(context.Background(), 6, 2, func(i int) error {
+		return failures[i]
+	})
+
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected Errors, got %T", err)
+	}
+
+	if len(errs) != 6 {
+		t.Fatalf("expected 6 errors, got %d", len(errs))
+	}
+
+	for i, e := range errs {
+		if e != failures[i] {
+			t.Errorf("item %d: expected %v, got %v", i, failures[i], e)
+		}
+	}
+}
+
+func TestExecuteConcurrencyBound(t *testing.T) {
+	const concurrency = 3
+
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+	)
+
+	err := Execute(context.Background(), 20, concurrency, func(i int) error {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if peak > concurrency {
+		t.Fatalf("expected at most %d concurrent items, got %d", concurrency, peak)
+	}
+
+	if peak < 2 {
+		t.Fatalf("expected items to run concurrently, peak was %d", peak)
+	}
+}
+
+func TestExecuteDefaultConcurrency(t *testing.T) {
+	var (
+		running int32
+		peak    int32
+	)
+
+	err := Execute(context.Background(), 20, 0, func(i int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if peak > defaultConcurrency {
+		t.Fatalf("expected at most %d concurrent items, got %d", defaultConcurrency, peak)
+	}
+}
+
+func TestExecuteCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		started = make(map[int]bool)
+	)
+
+	err := Execute(ctx, 5, 1, func(i int) error {
+		mu.Lock()
+		started[i] = true
+		mu.Unlock()
+
+		if i == 1 {
+			cancel()
+		}
+
+		return nil
+	})
+
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected Errors, got %T", err)
+	}
+
+	for i, e := range errs {
+		if started[i] {
+			if e != nil {
+				t.Errorf("item %d started and succeeded, got error %v", i, e)
+			}
+			continue
+		}
+
+		if !errors.Is(e, context.Canceled) {
+			t.Errorf("item %d not started: expected context.Canceled, got %v", i, e)
+		}
+	}
+
+	if started[4] {
+		t.Fatal("expected items after cancellation not to be started")
+	}
+}
+
+func TestExecuteCancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Execute(ctx, 3, 2, func(i int) error {
+		t.Fatal("fn must not be called")
+		return nil
+	})
+
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected Errors, got %T", err)
+	}
+
+	for i, e := range errs {
+		if e != context.Canceled {
+			t.Errorf("item %d: expected context.Canceled, got %v", i, e)
+		}
+	}
+}
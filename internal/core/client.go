@@ -8,29 +8,59 @@
 package core
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/dobyte/http"
-
 	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/sign"
 	"github.com/dobyte/tencent-im/internal/types"
 )
 
 const (
-	TIMHostForSG = "https://adminapisgp.im.qcloud.com"
-	TIMHostForCN = "https://console.tim.qq.com"
-	TIMHostForID = "https://adminapiidn.im.qcloud.com"
-	//defaultBaseUrl     = "https://adminapiger.im.qcloud.com"
+	TIMHostForSG       = "https://adminapisgp.im.qcloud.com"
+	TIMHostForCN       = "https://console.tim.qq.com"
+	TIMHostForID       = "https://adminapiidn.im.qcloud.com"
+	TIMHostForKR       = "https://adminapikr.im.qcloud.com"
+	TIMHostForGER      = "https://adminapiger.im.qcloud.com"
+	TIMHostForUSA      = "https://adminapiusa.im.qcloud.com"
 	defaultVersion     = "v4"
 	defaultContentType = "json"
-	defaultExpiration  = 3600
+	DefaultExpiration  = 3600 // UserSig默认过期时间（秒）
+	defaultRefreshGap  = 60
+	defaultRetryWait   = 100 * time.Millisecond
+	defaultTimeout     = 30 * time.Second
+)
+
+const (
+	RegionCN  = "cn"  // 中国
+	RegionSG  = "sg"  // 新加坡
+	RegionID  = "id"  // 雅加达
+	RegionKR  = "kr"  // 首尔
+	RegionGER = "ger" // 法兰克福
+	RegionUSA = "usa" // 硅谷
 )
 
 var invalidResponse = NewError(enum.InvalidResponseCode, "invalid response")
 
+// regionHosts 各地域的接口域名
+var regionHosts = map[string]string{
+	RegionCN:  TIMHostForCN,
+	RegionSG:  TIMHostForSG,
+	RegionID:  TIMHostForID,
+	RegionKR:  TIMHostForKR,
+	RegionGER: TIMHostForGER,
+	RegionUSA: TIMHostForUSA,
+}
+
 type Client interface {
 	// Get GET请求
 	Get(serviceName string, command string, data interface{}, resp interface{}) error
@@ -42,30 +72,86 @@ type Client interface {
 	Patch(serviceName string, command string, data interface{}, resp interface{}) error
 	// Delete DELETE请求
 	Delete(serviceName string, command string, data interface{}, resp interface{}) error
+	// WithContext 获取绑定了上下文的客户端，请求及重试等待将在上下文取消或超时后终止
+	WithContext(ctx context.Context) Client
+	// Context 获取客户端绑定的上下文
+	Context() context.Context
+	// HTTPClient 获取发起请求使用的HTTP客户端
+	HTTPClient() *http.Client
+	// CallRaw 以POST方式调用指定接口并返回原始的JSON响应，可用于调用SDK尚未支持的接口或字段
+	CallRaw(serviceName string, command string, data interface{}) (json.RawMessage, error)
 }
 
 type client struct {
-	client          *http.Client
-	opt             *Options
-	userSig         string
-	userSigExpireAt int64
+	ctx      context.Context
+	client   *http.Client
+	opt      *Options
+	limiter  *limiter
+	logger   Logger
+	observer Observer
+	sig      *userSig
+	host     string
+	hostErr  error
+}
+
+// rawResp 原始响应
+type rawResp struct {
+	types.ActionBaseResp
+	raw json.RawMessage
+}
+
+// UnmarshalJSON 保留原始响应，并解析其中的错误信息
+func (r *rawResp) UnmarshalJSON(data []byte) error {
+	r.raw = append(r.raw[:0], data...)
+	return json.Unmarshal(data, &r.ActionBaseResp)
+}
+
+// userSig 缓存的管理员签名
+type userSig struct {
+	mu       sync.Mutex
+	userSig  string
+	expireAt int64
 }
 
 type Options struct {
-	AppId         int    // 应用SDKAppID，可在即时通信 IM 控制台 的应用卡片中获取。
-	AppSecret     string // 密钥信息，可在即时通信 IM 控制台 的应用详情页面中获取，具体操作请参见 获取密钥
-	UserId        string // 用户ID
-	Expiration    int    // UserSig过期时间
-	TIMServerHost string //tencent IM 域名
+	AppId         int           // 应用SDKAppID，可在即时通信 IM 控制台 的应用卡片中获取。
+	AppSecret     string        // 密钥信息，可在即时通信 IM 控制台 的应用详情页面中获取，具体操作请参见 获取密钥
+	UserId        string        // 用户ID
+	Expiration    int           // UserSig过期时间
+	RefreshGap    int           // UserSig提前刷新时间（秒），默认为60秒，且不超过过期时间的一半
+	MaxRetries    int           // 请求失败时的最大重试次数，默认不重试；频率超限时均会重试，网络异常、服务端5xx及超时等结果不确定的错误仅对查询及覆盖写入等幂等接口重试
+	RetryInterval time.Duration // 重试的基础间隔时间，按指数退避并附加随机抖动，默认为100毫秒
+	RateLimit     int           // 每秒最多发起的请求数，默认不限制
+	Region        string        // 应用所在地域，用于选择接口域名，默认为中国
+	TIMServerHost string        // 接口域名，如 https://console.tim.qq.com ，设置后将忽略 Region
+	HTTPClient    *http.Client  // 发起请求使用的HTTP客户端，可用于自定义超时、连接池及代理等配置，默认使用超时时间为30秒的客户端
+	Logger        Logger        // 请求日志，记录每次请求的接口、请求ID、耗时及错误码，默认不输出
+	Observer      Observer      // 请求观测，每次请求结束后回调接口、状态码、错误码及耗时，可用于上报监控指标
 }
 
 func NewClient(opt *Options) Client {
 	rand.Seed(time.Now().UnixNano())
 	c := new(client)
+	c.ctx = context.Background()
 	c.opt = opt
-	c.client = http.NewClient()
-	c.client.SetContentType(http.ContentTypeJson)
-	c.client.SetBaseUrl(opt.TIMServerHost)
+	c.sig = &userSig{}
+	c.client = opt.HTTPClient
+
+	if c.client == nil {
+		c.client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	c.logger = opt.Logger
+	if c.logger == nil {
+		c.logger = nopLogger{}
+	}
+
+	c.observer = opt.Observer
+	c.host, c.hostErr = resolveHost(opt.Region, opt.TIMServerHost)
+
+	if opt.RateLimit > 0 {
+		c.limiter = newLimiter(opt.RateLimit)
+	}
 
 	return c
 }
@@ -95,14 +181,122 @@ func (c *client) Delete(serviceName string, command string, data interface{}, re
 	return c.request(http.MethodDelete, serviceName, command, data, resp)
 }
 
+// WithContext 获取绑定了上下文的客户端
+// 返回的客户端与原客户端共享签名缓存及限流器
+func (c *client) WithContext(ctx context.Context) Client {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+// Context 获取客户端绑定的上下文
+func (c *client) Context() context.Context {
+	return c.ctx
+}
+
+// CallRaw 以POST方式调用指定接口并返回原始的JSON响应
+// 请求使用客户端绑定的上下文；后台返回错误时同时返回原始响应及错误
+func (c *client) CallRaw(serviceName string, command string, data interface{}) (json.RawMessage, error) {
+	resp := &rawResp{}
+	err := c.request(http.MethodPost, serviceName, command, data, resp)
+
+	return resp.raw, err
+}
+
+// HTTPClient 获取发起请求使用的HTTP客户端
+func (c *client) HTTPClient() *http.Client {
+	return c.client
+}
+
 // request Request请求
-func (c *client) request(method, serviceName, command string, data, resp interface{}) error {
-	res, err := c.client.Request(method, c.buildUrl(serviceName, command), data)
+// 设置了最大重试次数时，将对可重试的错误进行指数退避重试（见 isRetryable），重试时沿用同一请求随机数，便于关联同一次调用的多次请求
+func (c *client) request(method, serviceName, command string, data, resp interface{}) (err error) {
+	random := rand.Int31()
+	for attempt := 0; ; attempt++ {
+		if err = c.doRequest(method, serviceName, command, random, data, resp); err == nil || attempt >= c.opt.MaxRetries || !isRetryable(command, err) {
+			break
+		}
+
+		timer := time.NewTimer(c.backoff(attempt))
+		select {
+		case <-c.ctx.Done():
+			timer.Stop()
+			return c.ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if e, ok := err.(*retryableError); ok {
+		err = e.error
+	}
+
+	return
+}
+
+// doRequest 发起一次请求
+func (c *client) doRequest(method, serviceName, command string, random int32, data, resp interface{}) (err error) {
+	if c.limiter != nil {
+		if err = c.limiter.wait(c.ctx); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	reqUrl, err := c.buildUrl(serviceName, command, random)
+	if err != nil {
+		return err
+	}
+
+	start, statusCode := time.Now(), 0
+	defer func() {
+		latency := time.Since(start)
+		c.logRequest(serviceName, command, random, latency, err)
+		if c.observer != nil {
+			c.observer.ObserveRequest(serviceName, command, statusCode, errorCode(err), latency)
+		}
+	}()
+
+	c.logger.Debugf("tim request %s %s body=%s", method, redactUrl(reqUrl), body)
+
+	req, err := http.NewRequestWithContext(c.ctx, method, reqUrl, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		if c.ctx.Err() != nil {
+			return err
+		}
+		return &retryableError{err}
+	}
+	defer res.Body.Close()
+	statusCode = res.StatusCode
 
-	if err = res.Scan(resp); err != nil {
+	if body, err = ioutil.ReadAll(res.Body); err != nil {
+		return err
+	}
+
+	c.logger.Debugf("tim response %s/%s request_id=%d status=%d body=%s", serviceName, command, random, res.StatusCode, body)
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return &retryableError{fmt.Errorf("unexpected http status: %s", res.Status)}
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected http status: %s", res.Status)
+	}
+
+	if err = json.Unmarshal(body, resp); err != nil {
 		return err
 	}
 
@@ -125,26 +319,83 @@ func (c *client) request(method, serviceName, command string, data, resp interfa
 	return nil
 }
 
+// backoff 计算第attempt次重试前的等待时间
+func (c *client) backoff(attempt int) time.Duration {
+	interval := c.opt.RetryInterval
+	if interval <= 0 {
+		interval = defaultRetryWait
+	}
+
+	wait := interval << uint(attempt)
+	return wait + time.Duration(rand.Int63n(int64(interval)))
+}
+
+// resolveHost 解析接口域名
+// 优先使用指定的接口域名，否则按地域选择，均未设置时使用中国地域的域名
+func resolveHost(region, host string) (string, error) {
+	if host = strings.TrimRight(host, "/"); host != "" {
+		if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+			host = "https://" + host
+		}
+		return host, nil
+	}
+
+	if region == "" {
+		return TIMHostForCN, nil
+	}
+
+	if host, ok := regionHosts[strings.ToLower(region)]; ok {
+		return host, nil
+	}
+
+	return "", fmt.Errorf("unknown region: %s", region)
+}
+
 // buildUrl 构建一个请求URL
-func (c *client) buildUrl(serviceName string, command string) string {
-	format := "/%s/%s/%s?sdkappid=%d&identifier=%s&usersig=%s&random=%d&contenttype=%s"
-	random := rand.Int31()
-	userSig := c.getUserSig()
-	return fmt.Sprintf(format, defaultVersion, serviceName, command, c.opt.AppId, c.opt.UserId, userSig, random, defaultContentType)
+// random 为请求的随机数，同时作为请求ID用于日志追踪
+func (c *client) buildUrl(serviceName string, command string, random int32) (string, error) {
+	if c.hostErr != nil {
+		return "", c.hostErr
+	}
+
+	userSig, err := c.getUserSig()
+	if err != nil {
+		return "", err
+	}
+
+	format := "%s/%s/%s/%s?sdkappid=%d&identifier=%s&usersig=%s&random=%d&contenttype=%s"
+	return fmt.Sprintf(format, c.host, defaultVersion, serviceName, command, c.opt.AppId, url.QueryEscape(c.opt.UserId), url.QueryEscape(userSig), random, defaultContentType), nil
 }
 
 // getUserSig 获取签名
-func (c *client) getUserSig() string {
-	now, expiration := time.Now(), c.opt.Expiration
+// 签名会被缓存，并在过期前 RefreshGap 秒重新生成
+func (c *client) getUserSig() (string, error) {
+	now, expiration, refreshGap := time.Now(), c.opt.Expiration, c.opt.RefreshGap
 
 	if expiration <= 0 {
-		expiration = defaultExpiration
+		expiration = DefaultExpiration
+	}
+
+	if refreshGap <= 0 {
+		refreshGap = defaultRefreshGap
 	}
 
-	if c.userSig == "" || c.userSigExpireAt <= now.Unix() {
-		c.userSig, _ = sign.GenUserSig(c.opt.AppId, c.opt.AppSecret, c.opt.UserId, expiration)
-		c.userSigExpireAt = now.Add(time.Duration(expiration) * time.Second).Unix()
+	if refreshGap > expiration/2 {
+		refreshGap = expiration / 2
+	}
+
+	c.sig.mu.Lock()
+	defer c.sig.mu.Unlock()
+
+	if c.sig.userSig == "" || c.sig.expireAt-int64(refreshGap) <= now.Unix() {
+		sig, err := sign.GenUserSig(c.opt.AppId, c.opt.AppSecret, c.opt.UserId, expiration)
+		if err != nil {
+			return "", err
+		}
+
+		c.sig.userSig = sig
+		c.sig.expireAt = now.Add(time.Duration(expiration) * time.Second).Unix()
 	}
 
-	return c.userSig
+	return c.sig.userSig, nil
 }
@@ -7,6 +7,8 @@
 
 package core
 
+import "errors"
+
 type Error interface {
 	error
 	Code() int
@@ -36,3 +38,15 @@ func (e *respError) Code() int {
 func (e *respError) Message() string {
 	return e.message
 }
+
+// Is 错误码相同即视为同一错误，以便通过 errors.Is 判断后台返回的错误
+func (e *respError) Is(target error) bool {
+	t, ok := target.(Error)
+	return ok && t.Code() == e.code
+}
+
+// IsErrorCode 判断错误是否为指定错误码的错误
+func IsErrorCode(err error, code int) bool {
+	var e Error
+	return errors.As(err, &e) && e.Code() == code
+}
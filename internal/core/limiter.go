@@ -0,0 +1,56 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/27 11:31 上午
+ * @Desc: 请求限流
+ */
+
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter 令牌桶限流器，每秒产生qps个令牌，桶容量为qps
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    time.Duration
+	next     time.Time
+}
+
+func newLimiter(qps int) *limiter {
+	interval := time.Second / time.Duration(qps)
+	return &limiter{interval: interval, burst: interval * time.Duration(qps-1)}
+}
+
+// wait 等待获取一个令牌，上下文取消或超时后归还令牌并返回上下文的错误
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if earliest := now.Add(-l.burst); l.next.Before(earliest) {
+		l.next = earliest
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.next = l.next.Add(-l.interval)
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterBurst(t *testing.T) {
+	l := newLimiter(5)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("wait %d: unexpected error %v", i, err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("expected burst of 5 without waiting, took %s", elapsed)
+	}
+}
+
+func TestLimiterThrottle(t *testing.T) {
+	l := newLimiter(10)
+
+	for i := 0; i < 10; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("wait %d: unexpected error %v", i, err)
+		}
+	}
+
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected wait of about %s after the burst, took %s", l.interval, elapsed)
+	}
+}
+
+func TestLimiterCancelReturnsToken(t *testing.T) {
+	l := newLimiter(1)
+
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	l.mu.Lock()
+	next := l.next
+	l.mu.Unlock()
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := l.wait(ctx)
+		cancel()
+
+		if err != context.DeadlineExceeded {
+			t.Fatalf("wait %d: expected context.DeadlineExceeded, got %v", i, err)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.next.Equal(next) {
+		t.Fatalf("expected cancelled waits to return their tokens, next moved by %s", l.next.Sub(next))
+	}
+}
+
+func TestLimiterCancelled(t *testing.T) {
+	l := newLimiter(1)
+
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if err := l.wait(ctx); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("expected cancelled wait to return immediately, took %s", elapsed)
+	}
+}
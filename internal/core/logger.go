@@ -0,0 +1,79 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/27 11:31 上午
+ * @Desc: 请求日志
+ */
+
+package core
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/dobyte/tencent-im/internal/enum"
+)
+
+const redacted = "******"
+
+// Logger 日志接口
+// Infof 记录每次请求的摘要，Debugf 记录请求及响应内容（签名已脱敏）
+type Logger interface {
+	Infof(format string, args ...interface{})
+	Debugf(format string, args ...interface{})
+}
+
+// nopLogger 默认日志，不输出任何内容
+type nopLogger struct{}
+
+func (nopLogger) Infof(format string, args ...interface{}) {}
+
+func (nopLogger) Debugf(format string, args ...interface{}) {}
+
+// logRequest 记录一次请求的摘要
+func (c *client) logRequest(serviceName, command string, requestId int32, latency time.Duration, err error) {
+	if err == nil {
+		c.logger.Infof("tim request %s/%s request_id=%d latency=%s code=%d", serviceName, command, requestId, latency, enum.SuccessCode)
+		return
+	}
+
+	if e, ok := err.(*retryableError); ok {
+		err = e.error
+	}
+
+	c.logger.Infof("tim request %s/%s request_id=%d latency=%s code=%d error=%s", serviceName, command, requestId, latency, errorCode(err), err.Error())
+}
+
+// errorCode 获取请求错误对应的错误码
+// 非后台返回的错误（网络异常、上下文取消等）统一视为无效响应
+func errorCode(err error) int {
+	if err == nil {
+		return enum.SuccessCode
+	}
+
+	if e, ok := err.(*retryableError); ok {
+		err = e.error
+	}
+
+	if e, ok := err.(Error); ok {
+		return e.Code()
+	}
+
+	return enum.InvalidResponseCode
+}
+
+// redactUrl 脱敏请求URL中的签名
+func redactUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return redacted
+	}
+
+	query := u.Query()
+	if query.Get("usersig") != "" {
+		query.Set("usersig", redacted)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
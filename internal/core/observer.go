@@ -0,0 +1,17 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/27 11:31 上午
+ * @Desc: 请求观测
+ */
+
+package core
+
+import "time"
+
+// Observer 请求观测接口，可用于上报请求耗时、状态码及错误码等监控指标
+type Observer interface {
+	// ObserveRequest 每次请求（含重试）结束后调用
+	// statusCode 为HTTP状态码，未收到响应时为0；errorCode 为错误码，成功时为0
+	ObserveRequest(service, command string, statusCode, errorCode int, d time.Duration)
+}
@@ -0,0 +1,91 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/27 11:31 上午
+ * @Desc: 请求重试
+ */
+
+package core
+
+// rejectedCodes 请求未被处理的错误码，任意接口均可安全重试
+var rejectedCodes = map[int]bool{
+	60007: true, // REST 接口调用频率超过限制，请降低请求频率
+	60011: true, // SDKAppID 请求频率超限，请降低请求频率
+}
+
+// uncertainCodes 请求结果不确定的错误码，仅幂等接口可重试
+var uncertainCodes = map[int]bool{
+	20004: true, // 网络异常，请重试
+	20005: true, // 服务器内部错误，请重试
+	60008: true, // 服务请求超时或 HTTP 请求格式错误，请检查并重试
+}
+
+// idempotentCommands 幂等的接口，重复请求不会产生额外的副作用
+// 包括查询接口及以覆盖方式写入的接口；创建、导入消息、添加成员、发送消息及推送等接口不在此列，
+// 这些接口的首次请求可能已成功，因此网络异常等结果不确定的错误不会重试
+var idempotentCommands = map[string]bool{
+	// 查询
+	"account_check":          true,
+	"admin_getroammsg":       true,
+	"black_list_check":       true,
+	"black_list_get":         true,
+	"friend_check":           true,
+	"friend_get":             true,
+	"friend_get_list":        true,
+	"get_appid_group_list":   true,
+	"get_c2c_unread_msg_num": true,
+	"get_group_attr":         true,
+	"get_group_counter":      true,
+	"get_group_info":         true,
+	"get_group_member_info":  true,
+	"get_group_shutted_uin":  true,
+	"get_history":            true,
+	"get_joined_group_list":  true,
+	"get_list":               true,
+	"get_online_member_num":  true,
+	"get_role_in_group":      true,
+	"getappinfo":             true,
+	"getnospeaking":          true,
+	"group_get":              true,
+	"group_msg_get_simple":   true,
+	"im_get_attr":            true,
+	"im_get_attr_name":       true,
+	"im_get_tag":             true,
+	"portrait_get":           true,
+	"query_online_status":    true,
+	"GetIPList":              true,
+
+	// 覆盖写入
+	"account_import":           true,
+	"multiaccount_import":      true,
+	"portrait_set":             true,
+	"setnospeaking":            true,
+	"friend_update":            true,
+	"modify_group_base_info":   true,
+	"modify_group_member_info": true,
+	"modify_group_attr":        true,
+	"forbid_send_msg":          true,
+	"set_unread_msg_num":       true,
+	"admin_set_msg_read":       true,
+	"im_set_attr_name":         true,
+	"im_set_attr":              true,
+}
+
+// retryableError 可重试的错误（网络异常及服务端5xx）
+type retryableError struct {
+	error
+}
+
+// isRetryable 是否为可重试的错误
+// 频率超限的请求未被处理，任意接口均可重试；网络异常、服务端5xx及超时等错误无法确定请求是否已被处理，仅幂等接口重试
+func isRetryable(command string, err error) bool {
+	if _, ok := err.(*retryableError); ok {
+		return idempotentCommands[command]
+	}
+
+	if e, ok := err.(Error); ok {
+		return rejectedCodes[e.Code()] || (uncertainCodes[e.Code()] && idempotentCommands[command])
+	}
+
+	return false
+}
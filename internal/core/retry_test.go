@@ -0,0 +1,124 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		name    string
+		command string
+		err     error
+		expect  bool
+	}{
+		{"rate limited query", "get_group_info", NewError(60007, "rate limited"), true},
+		{"rate limited write", "sendmsg", NewError(60007, "rate limited"), true},
+		{"app rate limited write", "create_group", NewError(60011, "app rate limited"), true},
+		{"network error query", "get_group_info", NewError(20004, "network error"), true},
+		{"network error write", "sendmsg", NewError(20004, "network error"), false},
+		{"internal error overwrite", "portrait_set", NewError(20005, "internal error"), true},
+		{"internal error write", "add_group_member", NewError(20005, "internal error"), false},
+		{"timeout query", "portrait_get", NewError(60008, "timeout"), true},
+		{"timeout write", "im_push", NewError(60008, "timeout"), false},
+		{"transport error query", "friend_get", &retryableError{errors.New("connection reset")}, true},
+		{"transport error write", "sendmsg", &retryableError{errors.New("connection reset")}, false},
+		{"5xx overwrite", "modify_group_base_info", &retryableError{errors.New("unexpected http status: 502 Bad Gateway")}, true},
+		{"5xx write", "import_group_msg", &retryableError{errors.New("unexpected http status: 502 Bad Gateway")}, false},
+		{"other backend error", "get_group_info", NewError(10010, "group not found"), false},
+		{"plain error", "get_group_info", errors.New("unexpected http status: 400 Bad Request"), false},
+		{"unknown command", "unknown_command", NewError(20004, "network error"), false},
+	}
+
+	for _, c := range cases {
+		if actual := isRetryable(c.command, c.err); actual != c.expect {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expect, actual)
+		}
+	}
+}
+
+func TestCallRawRetry(t *testing.T) {
+	cases := []struct {
+		name     string
+		command  string
+		status   int
+		body     string
+		attempts int32
+	}{
+		{"rate limited write", "sendmsg", http.StatusOK, `{"ActionStatus":"FAIL","ErrorCode":60007,"ErrorInfo":"rate limited"}`, 3},
+		{"uncertain query", "get_group_info", http.StatusOK, `{"ActionStatus":"FAIL","ErrorCode":20004,"ErrorInfo":"network error"}`, 3},
+		{"uncertain write", "sendmsg", http.StatusOK, `{"ActionStatus":"FAIL","ErrorCode":20004,"ErrorInfo":"network error"}`, 1},
+		{"5xx query", "get_group_info", http.StatusBadGateway, ``, 3},
+		{"5xx write", "sendmsg", http.StatusBadGateway, ``, 1},
+		{"other backend error", "get_group_info", http.StatusOK, `{"ActionStatus":"FAIL","ErrorCode":10010,"ErrorInfo":"group not found"}`, 1},
+	}
+
+	for _, c := range cases {
+		var attempts int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(c.status)
+			fmt.Fprint(w, c.body)
+		}))
+
+		cli := NewClient(&Options{
+			AppId:         1400000000,
+			AppSecret:     "secret",
+			UserId:        "administrator",
+			MaxRetries:    2,
+			RetryInterval: time.Millisecond,
+			TIMServerHost: server.URL,
+		})
+
+		if _, err := cli.CallRaw("service", c.command, map[string]interface{}{}); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+
+		server.Close()
+
+		if attempts != c.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", c.name, c.attempts, attempts)
+		}
+	}
+}
+
+func TestCallRawRetrySucceeds(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			fmt.Fprint(w, `{"ActionStatus":"FAIL","ErrorCode":60007,"ErrorInfo":"rate limited"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ActionStatus":"OK","ErrorCode":0,"ErrorInfo":"","MsgSeq":1}`)
+	}))
+	defer server.Close()
+
+	cli := NewClient(&Options{
+		AppId:         1400000000,
+		AppSecret:     "secret",
+		UserId:        "administrator",
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+		TIMServerHost: server.URL,
+	})
+
+	raw, err := cli.CallRaw("openim", "sendmsg", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+
+	if string(raw) != `{"ActionStatus":"OK","ErrorCode":0,"ErrorInfo":"","MsgSeq":1}` {
+		t.Fatalf("unexpected raw response %s", raw)
+	}
+}
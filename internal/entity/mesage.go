@@ -21,6 +21,8 @@ var (
 	errNotSetMsgContent   = errors.New("message content is not set")
 )
 
+const maxMsgLifeTime = 604800 // 消息离线保存最长时长（单位：秒）
+
 type Message struct {
 	sender      string           // 发送方UserId
 	lifeTime    int              // 消息离线保存时长（单位：秒），最长为7天（604800秒）
@@ -50,11 +52,13 @@ func (m *Message) GetLifeTime() int {
 }
 
 // SetRandom 设置消息随机数
+// 后台会依据消息随机数对同一秒内的消息去重，重发超时的消息时可设置为首次发送时的随机数
 func (m *Message) SetRandom(random uint32) {
 	m.random = random
 }
 
 // GetRandom 获取消息随机数
+// 未设置时将生成随机数并保存在消息中，重复发送同一消息实体时沿用该随机数
 func (m *Message) GetRandom() uint32 {
 	if m.random == 0 {
 		m.random = rand.Uint32()
@@ -109,6 +113,11 @@ func (m *Message) SetContent(msgContent ...interface{}) {
 	m.AddContent(msgContent...)
 }
 
+// SetBody 设置消息体（设置会冲掉之前的消息内容）
+func (m *Message) SetBody(body ...*types.MsgBody) {
+	m.body = append(m.body[0:0], body...)
+}
+
 // GetBody 获取消息体
 func (m *Message) GetBody() []*types.MsgBody {
 	return m.body
@@ -139,16 +148,19 @@ func (m *Message) GetOfflinePushInfo() *types.OfflinePushInfo {
 	}
 }
 
+// CheckOfflinePushArgError 检测离线推送参数错误
+func (m *Message) CheckOfflinePushArgError() error {
+	if m.offlinePush == nil {
+		return nil
+	}
+
+	return m.offlinePush.checkError()
+}
+
 // CheckLifeTimeArgError 检测参数错误
 func (m *Message) CheckLifeTimeArgError() error {
-	if m.body != nil && len(m.body) > 0 {
-		for _, item := range m.body {
-			if item.MsgType == "" {
-				return errInvalidMsgContent
-			}
-		}
-	} else {
-		return errNotSetMsgContent
+	if m.lifeTime < 0 || m.lifeTime > maxMsgLifeTime {
+		return errInvalidMsgLifeTime
 	}
 
 	return nil
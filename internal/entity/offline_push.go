@@ -8,10 +8,24 @@
 package entity
 
 import (
+    "errors"
+    "path"
+    "strings"
+
     "github.com/dobyte/tencent-im/internal/conv"
     "github.com/dobyte/tencent-im/internal/types"
 )
 
+var (
+    errInvalidPushFlag           = errors.New("offline push's push flag is invalid")
+    errInvalidPushSound          = errors.New("offline push's sound must be a file name with extension")
+    errInvalidBadgeMode          = errors.New("offline push's apns badge mode is invalid")
+    errInvalidMutableContent     = errors.New("offline push's apns mutable content is invalid")
+    errInvalidVivoClassification = errors.New("offline push's vivo classification is invalid")
+    errInvalidHuaWeiImportance   = errors.New("offline push's huawei importance is invalid")
+    errInvalidHuaweiIntentParam  = errors.New("offline push's huawei intent param is invalid")
+)
+
 type offlinePush struct {
     pushFlag    int                // 推送标识。0表示推送，1表示不离线推送。
     title       string             // 离线推送标题。该字段为 iOS 和 Android 共用。
@@ -25,126 +39,204 @@ func newOfflinePush() *offlinePush {
     return &offlinePush{}
 }
 
-// SetPushFlag 设置推送消息
-func (o *offlinePush) SetPushFlag(pushFlag types.PushFlag) {
+// SetPushFlag 设置推送标识
+func (o *offlinePush) SetPushFlag(pushFlag types.PushFlag) *offlinePush {
     o.pushFlag = int(pushFlag)
+    return o
 }
 
 // SetTitle 设置离线推送标题
-func (o *offlinePush) SetTitle(title string) {
+func (o *offlinePush) SetTitle(title string) *offlinePush {
     o.title = title
+    return o
 }
 
 // SetDesc 设置离线推送内容
-func (o *offlinePush) SetDesc(desc string) {
+func (o *offlinePush) SetDesc(desc string) *offlinePush {
     o.desc = desc
+    return o
 }
 
 // SetExt 设置离线推送透传内容
-func (o *offlinePush) SetExt(ext interface{}) {
+func (o *offlinePush) SetExt(ext interface{}) *offlinePush {
     o.ext = conv.String(ext)
+    return o
 }
 
 // SetAndroidSound 设置Android离线推送声音文件路径
-func (o *offlinePush) SetAndroidSound(sound string) {
+func (o *offlinePush) SetAndroidSound(sound string) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.Sound = sound
+    return o
 }
 
 // SetAndroidHuaWeiChannelId 设置华为手机 EMUI 10.0 及以上的通知渠道字段
-func (o *offlinePush) SetAndroidHuaWeiChannelId(channelId string) {
+func (o *offlinePush) SetAndroidHuaWeiChannelId(channelId string) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.HuaWeiChannelID = channelId
+    return o
 }
 
 // SetAndroidXiaoMiChannelId 设置小米手机 MIUI 10 及以上的通知类别（Channel）适配字段
-func (o *offlinePush) SetAndroidXiaoMiChannelId(channelId string) {
+func (o *offlinePush) SetAndroidXiaoMiChannelId(channelId string) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.XiaoMiChannelID = channelId
+    return o
 }
 
 // SetAndroidOppoChannelId 设置OPPO手机 Android 8.0 及以上的 NotificationChannel 通知适配字段
-func (o *offlinePush) SetAndroidOppoChannelId(channelId string) {
+func (o *offlinePush) SetAndroidOppoChannelId(channelId string) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.OPPOChannelID = channelId
+    return o
 }
 
 // SetAndroidGoogleChannelId 设置Google 手机 Android 8.0 及以上的通知渠道字段
-func (o *offlinePush) SetAndroidGoogleChannelId(channelId string) {
+func (o *offlinePush) SetAndroidGoogleChannelId(channelId string) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.GoogleChannelID = channelId
+    return o
 }
 
 // SetAndroidVivoClassification 设置VIVO 手机推送消息分类，“0”代表运营消息，“1”代表系统消息，不填默认为1
-func (o *offlinePush) SetAndroidVivoClassification(classification types.VivoClassification) {
+func (o *offlinePush) SetAndroidVivoClassification(classification types.VivoClassification) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.VIVOClassification = int(classification)
+    return o
 }
 
 // SetAndroidHuaWeiImportance 设置华为推送通知消息分类
-func (o *offlinePush) SetAndroidHuaWeiImportance(importance types.HuaWeiImportance) {
+func (o *offlinePush) SetAndroidHuaWeiImportance(importance types.HuaWeiImportance) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.HuaWeiImportance = string(importance)
+    return o
 }
 
 // SetAndroidExtAsHuaweiIntentParam 设置在控制台配置华为推送为“打开应用内指定页面”的前提下，传“1”表示将透传内容 Ext 作为 Intent 的参数，“0”表示将透传内容 Ext 作为 Action 参数。不填默认为0。
-func (o *offlinePush) SetAndroidExtAsHuaweiIntentParam(param types.HuaweiIntentParam) {
+func (o *offlinePush) SetAndroidExtAsHuaweiIntentParam(param types.HuaweiIntentParam) *offlinePush {
     if o.androidInfo == nil {
         o.androidInfo = &types.AndroidInfo{}
     }
     o.androidInfo.ExtAsHuaweiIntentParam = int(param)
+    return o
 }
 
 // SetApnsBadgeMode 设置IOS徽章计数模式
-func (o *offlinePush) SetApnsBadgeMode(badgeMode types.BadgeMode) {
+func (o *offlinePush) SetApnsBadgeMode(badgeMode types.BadgeMode) *offlinePush {
     if o.apnsInfo == nil {
         o.apnsInfo = &types.ApnsInfo{}
     }
     o.apnsInfo.BadgeMode = int(badgeMode)
+    return o
+}
+
+// SetApnsSound 设置APNs推送的声音文件名
+func (o *offlinePush) SetApnsSound(sound string) *offlinePush {
+    if o.apnsInfo == nil {
+        o.apnsInfo = &types.ApnsInfo{}
+    }
+    o.apnsInfo.Sound = sound
+    return o
 }
 
 // SetApnsTitle 设置APNs推送的标题
-func (o *offlinePush) SetApnsTitle(title string) {
+func (o *offlinePush) SetApnsTitle(title string) *offlinePush {
     if o.apnsInfo == nil {
         o.apnsInfo = &types.ApnsInfo{}
     }
     o.apnsInfo.Title = title
+    return o
 }
 
 // SetApnsSubTitle 设置APNs推送的子标题
-func (o *offlinePush) SetApnsSubTitle(subTitle string) {
+func (o *offlinePush) SetApnsSubTitle(subTitle string) *offlinePush {
     if o.apnsInfo == nil {
         o.apnsInfo = &types.ApnsInfo{}
     }
     o.apnsInfo.SubTitle = subTitle
+    return o
 }
 
 // SetApnsImage 设置APNs携带的图片地址
-func (o *offlinePush) SetApnsImage(image string) {
+func (o *offlinePush) SetApnsImage(image string) *offlinePush {
     if o.apnsInfo == nil {
         o.apnsInfo = &types.ApnsInfo{}
     }
     o.apnsInfo.Image = image
+    return o
 }
 
 // SetApnsMutableContent 设置iOS10的推送扩展开关
-func (o *offlinePush) SetApnsMutableContent(mutable types.MutableContent) {
+func (o *offlinePush) SetApnsMutableContent(mutable types.MutableContent) *offlinePush {
     if o.apnsInfo == nil {
         o.apnsInfo = &types.ApnsInfo{}
     }
     o.apnsInfo.MutableContent = int(mutable)
+    return o
+}
+
+// checkError 检测离线推送参数错误
+func (o *offlinePush) checkError() error {
+    if o.pushFlag != 0 && o.pushFlag != 1 {
+        return errInvalidPushFlag
+    }
+
+    if o.androidInfo != nil {
+        if !isValidSound(o.androidInfo.Sound) {
+            return errInvalidPushSound
+        }
+
+        if o.androidInfo.VIVOClassification != 0 && o.androidInfo.VIVOClassification != 1 {
+            return errInvalidVivoClassification
+        }
+
+        switch o.androidInfo.HuaWeiImportance {
+        case "", "LOW", "NORMAL":
+        default:
+            return errInvalidHuaWeiImportance
+        }
+
+        if o.androidInfo.ExtAsHuaweiIntentParam != 0 && o.androidInfo.ExtAsHuaweiIntentParam != 1 {
+            return errInvalidHuaweiIntentParam
+        }
+    }
+
+    if o.apnsInfo != nil {
+        if !isValidSound(o.apnsInfo.Sound) {
+            return errInvalidPushSound
+        }
+
+        if o.apnsInfo.BadgeMode != 0 && o.apnsInfo.BadgeMode != 1 {
+            return errInvalidBadgeMode
+        }
+
+        if o.apnsInfo.MutableContent != 0 && o.apnsInfo.MutableContent != 1 {
+            return errInvalidMutableContent
+        }
+    }
+
+    return nil
+}
+
+// isValidSound 检测推送声音文件，未设置或为带扩展名的文件名时有效
+func isValidSound(sound string) bool {
+    if sound == "" {
+        return true
+    }
+
+    return strings.TrimSpace(sound) == sound && path.Ext(sound) != ""
 }
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dobyte/tencent-im/internal/conv"
 	"github.com/dobyte/tencent-im/internal/core"
 	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/types"
@@ -52,6 +53,12 @@ func (u *User) GetNickname() (nickname string, exist bool) {
 
 // SetGender 设置性别
 func (u *User) SetGender(gender types.GenderType) {
+	switch gender {
+	case enum.GenderTypeUnknown, enum.GenderTypeFemale, enum.GenderTypeMale:
+	default:
+		u.SetError(enum.InvalidParamsCode, "invalid gender type")
+	}
+
 	u.SetAttr(enum.StandardAttrGender, gender)
 }
 
@@ -77,7 +84,7 @@ func (u *User) GetBirthday() (birthday time.Time, exist bool) {
 	var v interface{}
 
 	if v, exist = u.GetAttr(enum.StandardAttrBirthday); exist {
-		if val := v.(string); val != "" {
+		if val := conv.String(v); val != "" && val != "0" {
 			birthday, _ = time.Parse("20060102", val)
 		}
 	}
@@ -172,6 +179,12 @@ func (u *User) GetSignature() (signature string, exist bool) {
 
 // SetAllowType 设置加好友验证方式
 func (u *User) SetAllowType(allowType types.AllowType) {
+	switch allowType {
+	case enum.AllowTypeNeedConfirm, enum.AllowTypeAllowAny, enum.AllowTypeDenyAny:
+	default:
+		u.SetError(enum.InvalidParamsCode, "invalid allow type")
+	}
+
 	u.SetAttr(enum.StandardAttrAllowType, allowType)
 }
 
@@ -236,6 +249,12 @@ func (u *User) GetMsgSettings() (settings uint, exist bool) {
 
 // SetAdminForbidType 设置管理员禁止加好友标识
 func (u *User) SetAdminForbidType(forbidType types.AdminForbidType) {
+	switch forbidType {
+	case enum.AdminForbidTypeNone, enum.AdminForbidTypeSendOut:
+	default:
+		u.SetError(enum.InvalidParamsCode, "invalid admin forbid type")
+	}
+
 	u.SetAttr(enum.StandardAttrAdminForbidType, forbidType)
 }
 
@@ -3,14 +3,21 @@ package sign
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -35,9 +42,41 @@ import (
  * expire - UserSig expiration time, in seconds. For example, 86400 indicates that the generated UserSig will expire one day after being generated.
  */
 func GenUserSig(sdkappid int, key string, userid string, expire int) (string, error) {
+	return GenUserSigContext(context.Background(), sdkappid, key, userid, expire)
+}
+
+// GenUserSigContext 签发UserSig，签发前检查ctx是否已取消或超时
+// GenUserSigContext Issue UserSig, returning early with the context error when ctx is done
+func GenUserSigContext(ctx context.Context, sdkappid int, key string, userid string, expire int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	return genSig(sdkappid, key, userid, expire, nil)
 }
 
+// SigOptions 签发UserSig的可选参数
+// SigOptions Optional parameters for issuing UserSig
+type SigOptions struct {
+	CompressionLevel int  // zlib压缩级别，零值为 zlib.NoCompression
+	AllowZeroExpire  bool // 是否允许签发有效期为0的票据
+}
+
+func defaultSigOptions() SigOptions {
+	return SigOptions{CompressionLevel: DefaultCompressionLevel}
+}
+
+// GenUserSigWithOptions 使用自定义参数签发UserSig，不影响全局的 DefaultCompressionLevel
+// GenUserSigWithOptions Issue UserSig with per-call options, leaving DefaultCompressionLevel untouched
+func GenUserSigWithOptions(sdkappid int, key string, userid string, expire int, opts SigOptions) (string, error) {
+	return genSigWithHash(newHMAC([]byte(key)), sdkappid, userid, expire, nil, opts)
+}
+
+// GenUserSigWithKeyBytes 使用字节切片形式的密钥签发UserSig，避免额外的字符串转换
+// GenUserSigWithKeyBytes Issue UserSig with a key held as a byte slice, avoiding a string conversion
+func GenUserSigWithKeyBytes(sdkappid int, key []byte, userid string, expire int) (string, error) {
+	return genSigWithHash(newHMAC(key), sdkappid, userid, expire, nil, defaultSigOptions())
+}
+
 func GenUserSigWithBuf(sdkappid int, key string, userid string, expire int, buf []byte) (string, error) {
 	return genSig(sdkappid, key, userid, expire, buf)
 }
@@ -97,7 +136,10 @@ func GenUserSigWithBuf(sdkappid int, key string, userid string, expire int, buf
  */
 
 func GenPrivateMapKey(sdkappid int, key string, userid string, expire int, roomid uint32, privilegeMap uint32) (string, error) {
-	var userbuf []byte = genUserBuf(userid, sdkappid, roomid, expire, privilegeMap, 0, "")
+	userbuf, err := genUserBuf(userid, sdkappid, roomid, expire, privilegeMap, 0, "")
+	if err != nil {
+		return "", err
+	}
 	return genSig(sdkappid, key, userid, expire, userbuf)
 }
 
@@ -155,12 +197,22 @@ func GenPrivateMapKey(sdkappid int, key string, userid string, expire int, roomi
  *  - privilegeMap == 0010 1010 == 42: Indicates that the UserID has only the permissions to enter the room and receive audio/video data.
  */
 func GenPrivateMapKeyWithStringRoomID(sdkappid int, key string, userid string, expire int, roomStr string, privilegeMap uint32) (string, error) {
-	var userbuf []byte = genUserBuf(userid, sdkappid, 0, expire, privilegeMap, 0, roomStr)
+	userbuf, err := genUserBuf(userid, sdkappid, 0, expire, privilegeMap, 0, roomStr)
+	if err != nil {
+		return "", err
+	}
 	return genSig(sdkappid, key, userid, expire, userbuf)
 }
 
+// maxUserBufFieldLength UserBuf中账号和字符串房间号的最大长度，由两个字节表示
+const maxUserBufFieldLength = 0xFFFF
+
 func genUserBuf(account string, dwSdkappid int, dwAuthID uint32,
-	dwExpTime int, dwPrivilegeMap uint32, dwAccountType uint32, roomStr string) []byte {
+	dwExpTime int, dwPrivilegeMap uint32, dwAccountType uint32, roomStr string) ([]byte, error) {
+	if len(account) > maxUserBufFieldLength || len(roomStr) > maxUserBufFieldLength {
+		return nil, ErrUserBufFieldTooLong
+	}
+
 	appid := uint32(dwSdkappid)
 	offset := 0
 	length := 1 + 2 + len(account) + 20 + len(roomStr)
@@ -208,7 +260,7 @@ func genUserBuf(account string, dwSdkappid int, dwAuthID uint32,
 	offset++
 
 	//dwExpTime now+300;
-	currTime := time.Now().Unix()
+	currTime := Now().Unix()
 	var expire = currTime + int64(dwExpTime)
 	userBuf[offset] = (byte)((expire & 0xFF000000) >> 24)
 	offset++
@@ -250,24 +302,108 @@ func genUserBuf(account string, dwSdkappid int, dwAuthID uint32,
 		}
 	}
 
-	return userBuf
+	return userBuf, nil
+}
+
+// PrivateMapKeyInfo PrivateMapKey中UserBuf解析后的内容
+// PrivateMapKeyInfo Decoded content of a PrivateMapKey userbuf
+type PrivateMapKeyInfo struct {
+	Version      uint8  // 版本号，0表示数字房间号，1表示字符串房间号
+	Account      string // 用户id
+	SdkAppID     uint32 // 应用id
+	RoomID       uint32 // 数字房间号
+	RoomStr      string // 字符串房间号
+	ExpireAt     int64  // 过期时间戳
+	PrivilegeMap uint32 // 权限位
+	AccountType  uint32 // 账号类型
+}
+
+// ParsePrivateMapKeyBuf 解析PrivateMapKey中的UserBuf
+// ParsePrivateMapKeyBuf Parse the userbuf embedded in a PrivateMapKey
+func ParsePrivateMapKeyBuf(buf []byte) (*PrivateMapKeyInfo, error) {
+	r := userBufReader{buf: buf}
+	info := &PrivateMapKeyInfo{Version: r.uint8()}
+	if info.Version > 1 {
+		return nil, ErrMalformedUserBuf
+	}
+	info.Account = string(r.bytes(int(r.uint16())))
+	info.SdkAppID = r.uint32()
+	info.RoomID = r.uint32()
+	info.ExpireAt = int64(r.uint32())
+	info.PrivilegeMap = r.uint32()
+	info.AccountType = r.uint32()
+	if info.Version == 1 {
+		info.RoomStr = string(r.bytes(int(r.uint16())))
+	}
+	if r.err || r.offset != len(buf) {
+		return nil, ErrMalformedUserBuf
+	}
+	return info, nil
+}
+
+type userBufReader struct {
+	buf    []byte
+	offset int
+	err    bool
+}
+
+func (r *userBufReader) bytes(n int) []byte {
+	if r.err || n > len(r.buf)-r.offset {
+		r.err = true
+		return nil
+	}
+	b := r.buf[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *userBufReader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *userBufReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *userBufReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
 }
 
 func genSig(sdkappid int, key string, identifier string, expire int, userbuf []byte) (string, error) {
-	currTime := time.Now().Unix()
+	return genSigWithHash(newHMAC([]byte(key)), sdkappid, identifier, expire, userbuf, defaultSigOptions())
+}
+
+func genSigWithHash(h hash.Hash, sdkappid int, identifier string, expire int, userbuf []byte, opts SigOptions) (string, error) {
+	if err := ValidateUserID(identifier); err != nil {
+		return "", err
+	}
+	if expire < 0 || (expire == 0 && !opts.AllowZeroExpire) {
+		return "", ErrNonPositiveExpire
+	}
+	currTime := Now().Unix()
 	sigDoc := userSig{
-		Version:    "2.0",
+		Version:    defaultSigVersion,
 		Identifier: identifier,
 		SdkAppID:   uint64(sdkappid),
 		Expire:     int64(expire),
 		Time:       currTime,
 		UserBuf:    userbuf,
 	}
-	sigDoc.Sig = sigDoc.sign(key)
+	sigDoc.Sig = sigDoc.signWithHash(h)
 
-	var b bytes.Buffer
-	w := newZlibWriter(&b)
-	defer zlibWriterPool.Put(w)
+	b := newBuffer()
+	defer bufferPool.Put(b)
+	w := newZlibWriter(b, opts.CompressionLevel)
+	defer releaseZlibWriter(w, opts.CompressionLevel)
 	if err := json.NewEncoder(w).Encode(sigDoc); err != nil {
 		return "", err
 	}
@@ -277,6 +413,70 @@ func genSig(sdkappid int, key string, identifier string, expire int, userbuf []b
 	return base64url.EncodeToString(b.Bytes()), nil
 }
 
+// maxUserIDLength userid的最大字节长度
+const maxUserIDLength = 32
+
+// ValidateUserID 校验userid是否合法：非空，长度不超过32字节，只包含大小写英文字母（a-zA-Z）、数字（0-9）及下划线和连词符
+// ValidateUserID Check that userid is not empty, is at most 32 bytes and only contains a-z, A-Z, 0-9, underscores and hyphens
+func ValidateUserID(userid string) error {
+	if userid == "" || len(userid) > maxUserIDLength {
+		return ErrInvalidUserID
+	}
+	for i := 0; i < len(userid); i++ {
+		switch c := userid[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+		default:
+			return ErrInvalidUserID
+		}
+	}
+	return nil
+}
+
+// GenUserSigBatch 批量签发UserSig，所有userid共用同一个HMAC实例
+// 返回成功签发的 userid => usersig 映射，若部分userid签发失败，同时返回 BatchError
+// GenUserSigBatch Issue UserSig for many userids while reusing a single HMAC instance.
+// Successfully issued sigs are always returned; failures are reported through a BatchError.
+func GenUserSigBatch(sdkappid int, key string, userids []string, expire int) (map[string]string, error) {
+	var (
+		h    = newHMAC([]byte(key))
+		sigs = make(map[string]string, len(userids))
+		errs BatchError
+	)
+	for _, userid := range userids {
+		sig, err := genSigWithHash(h, sdkappid, userid, expire, nil, defaultSigOptions())
+		if err != nil {
+			if errs == nil {
+				errs = make(BatchError)
+			}
+			errs[userid] = err
+			continue
+		}
+		sigs[userid] = sig
+	}
+	if errs != nil {
+		return sigs, errs
+	}
+	return sigs, nil
+}
+
+// BatchError 批量签发时各userid对应的错误
+// BatchError Errors of the userids that failed during batch issuing
+type BatchError map[string]error
+
+func (e BatchError) Error() string {
+	userids := make([]string, 0, len(e))
+	for userid := range e {
+		userids = append(userids, userid)
+	}
+	sort.Strings(userids)
+
+	msgs := make([]string, 0, len(userids))
+	for _, userid := range userids {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", userid, e[userid]))
+	}
+	return fmt.Sprintf("gen usersig failed for %d userids: %s", len(e), strings.Join(msgs, "; "))
+}
+
 // VerifyUserSig 检验UserSig在now时间点时是否有效
 // VerifyUserSig Check if UserSig is valid at now time
 func VerifyUserSig(sdkappid uint64, key string, userid string, usersig string, now time.Time) error {
@@ -284,7 +484,17 @@ func VerifyUserSig(sdkappid uint64, key string, userid string, usersig string, n
 	if err != nil {
 		return err
 	}
-	return sig.verify(sdkappid, key, userid, now, nil)
+	return sig.verify(sdkappid, []byte(key), userid, now, nil)
+}
+
+// VerifyUserSigDetail 检验UserSig在now时间点时是否有效，并返回距离过期的剩余时间（已过期时为负数）
+// VerifyUserSigDetail Check if UserSig is valid at now time and return the remaining validity (negative when expired)
+func VerifyUserSigDetail(sdkappid uint64, key string, userid string, usersig string, now time.Time) (time.Duration, error) {
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		return 0, err
+	}
+	return sig.remaining(now), sig.verify(sdkappid, []byte(key), userid, now, nil)
 }
 
 // VerifyUserSigWithBuf 检验带UserBuf的UserSig在now时间点是否有效
@@ -294,7 +504,20 @@ func VerifyUserSigWithBuf(sdkappid uint64, key string, userid string, usersig st
 	if err != nil {
 		return err
 	}
-	return sig.verify(sdkappid, key, userid, now, userbuf)
+	return sig.verify(sdkappid, []byte(key), userid, now, userbuf)
+}
+
+// VerifyAndExtract 检验UserSig在now时间点是否有效，有效时返回其中附带的UserBuf，未附带UserBuf时返回nil
+// VerifyAndExtract Check if UserSig is valid at now and return its embedded UserBuf, or nil when it carries none
+func VerifyAndExtract(sdkappid uint64, key string, userid string, usersig string, now time.Time) ([]byte, error) {
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		return nil, err
+	}
+	if err = sig.verify(sdkappid, []byte(key), userid, now, sig.UserBuf); err != nil {
+		return nil, err
+	}
+	return sig.UserBuf, nil
 }
 
 type userSig struct {
@@ -307,30 +530,65 @@ type userSig struct {
 	Sig        []byte `json:"TLS.sig,omitempty"`
 }
 
+// UserSigInfo UserSig解码后的内容
+// UserSigInfo Decoded content of a UserSig
+type UserSigInfo struct {
+	Version    string // 版本号
+	Identifier string // 用户id
+	SdkAppID   uint64 // 应用id
+	Expire     int64  // 有效期，单位秒
+	Time       int64  // 签发时间戳
+	UserBuf    []byte // 附带的UserBuf
+	Sig        string // 十六进制编码的签名
+}
+
+// DecodeUserSig 解码UserSig，不校验签名，仅用于调试或排查问题
+// DecodeUserSig Decode UserSig without verifying the signature, for debugging purposes only
+func DecodeUserSig(usersig string) (*UserSigInfo, error) {
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		return nil, err
+	}
+	return &UserSigInfo{
+		Version:    sig.Version,
+		Identifier: sig.Identifier,
+		SdkAppID:   sig.SdkAppID,
+		Expire:     sig.Expire,
+		Time:       sig.Time,
+		UserBuf:    sig.UserBuf,
+		Sig:        hex.EncodeToString(sig.Sig),
+	}, nil
+}
+
 func newUserSig(usersig string) (userSig, error) {
 	b, err := base64urlDecode(usersig)
 	if err != nil {
-		return userSig{}, err
+		return userSig{}, fmt.Errorf("%w: base64 decode: %v", ErrMalformedUserSig, err)
 	}
 	r, err := zlib.NewReader(bytes.NewReader(b))
 	if err != nil {
-		return userSig{}, err
+		return userSig{}, fmt.Errorf("%w: zlib: %v", ErrMalformedUserSig, err)
 	}
 	data, err := ioutil.ReadAll(r)
 	if err != nil {
-		return userSig{}, err
+		return userSig{}, fmt.Errorf("%w: zlib: %v", ErrMalformedUserSig, err)
 	}
 	if err = r.Close(); err != nil {
-		return userSig{}, err
+		return userSig{}, fmt.Errorf("%w: zlib: %v", ErrMalformedUserSig, err)
 	}
 	var sig userSig
 	if err = json.Unmarshal(data, &sig); err != nil {
-		return userSig{}, nil
+		return userSig{}, fmt.Errorf("%w: json: %v", ErrMalformedUserSig, err)
 	}
 	return sig, nil
 }
 
-func (u userSig) verify(sdkappid uint64, key string, userid string, now time.Time, userbuf []byte) error {
+// remaining 返回UserSig在now时间点距离过期的剩余时间
+func (u userSig) remaining(now time.Time) time.Duration {
+	return time.Unix(u.Time+u.Expire, 0).Sub(now)
+}
+
+func (u userSig) verify(sdkappid uint64, key []byte, userid string, now time.Time, userbuf []byte) error {
 	if sdkappid != u.SdkAppID {
 		return ErrSdkAppIDNotMatch
 	}
@@ -350,7 +608,11 @@ func (u userSig) verify(sdkappid uint64, key string, userid string, now time.Tim
 	} else if u.UserBuf != nil {
 		return ErrUserBufTypeNotMatch
 	}
-	if !bytes.Equal(u.sign(key), u.Sig) {
+	sig, err := u.sign(key)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(sig, u.Sig) {
 		return ErrSigNotMatch
 	}
 	return nil
@@ -365,8 +627,31 @@ var (
 	sigEnter      = []byte("\n")
 )
 
-func (u userSig) sign(key string) []byte {
-	h := hmac.New(sha256.New, []byte(key))
+// defaultSigVersion 签发票据时使用的版本号
+const defaultSigVersion = "2.0"
+
+// sigHashes 各版本票据签名使用的哈希算法
+var sigHashes = map[string]func() hash.Hash{
+	defaultSigVersion: sha256.New,
+}
+
+// newHMAC 创建默认版本票据使用的HMAC
+func newHMAC(key []byte) hash.Hash {
+	return hmac.New(sigHashes[defaultSigVersion], key)
+}
+
+// sign 按票据版本选择哈希算法计算签名
+func (u userSig) sign(key []byte) ([]byte, error) {
+	h, ok := sigHashes[u.Version]
+	if !ok {
+		return nil, ErrVersionNotSupported
+	}
+	return u.signWithHash(hmac.New(h, key)), nil
+}
+
+// signWithHash 使用给定的HMAC计算签名，计算前会重置其状态以便复用
+func (u userSig) signWithHash(h hash.Hash) []byte {
+	h.Reset()
 	h.Write(sigIdentifier)
 	h.Write([]byte(u.Identifier))
 	h.Write(sigEnter)
@@ -395,26 +680,62 @@ var (
 	ErrUserBufTypeNotMatch = errors.New("userbuf type not match")
 	ErrUserBufNotMatch     = errors.New("userbuf not match")
 	ErrSigNotMatch         = errors.New("sig not match")
+	ErrInvalidUserID       = errors.New("invalid userid")
+	ErrMalformedUserBuf    = errors.New("malformed userbuf")
+	ErrUserBufFieldTooLong = errors.New("userbuf account or roomstr too long")
+	ErrMalformedUserSig    = errors.New("malformed usersig")
+	ErrVersionNotSupported = errors.New("version not supported")
+	ErrNonPositiveExpire   = errors.New("expire must be positive")
 )
 
-var (
-	zlibWriterPool sync.Pool
-)
+// bufferPool 签发票据时使用的缓冲区池
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
 
-func newZlibWriter(w io.Writer) *zlib.Writer {
-	v := zlibWriterPool.Get()
-	if v == nil {
-		zw, err := zlib.NewWriterLevel(w, DefaultCompressionLevel)
-		if err != nil {
-			return zlib.NewWriter(w)
+func newBuffer() *bytes.Buffer {
+	b := bufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	return b
+}
+
+// zlibWriterPools 按压缩级别区分的zlib writer池
+var zlibWriterPools [zlib.BestCompression - zlib.HuffmanOnly + 1]sync.Pool
+
+func zlibWriterPoolOf(level int) *sync.Pool {
+	if level < zlib.HuffmanOnly || level > zlib.BestCompression {
+		return nil
+	}
+	return &zlibWriterPools[level-zlib.HuffmanOnly]
+}
+
+func newZlibWriter(w io.Writer, level int) *zlib.Writer {
+	if p := zlibWriterPoolOf(level); p != nil {
+		if v := p.Get(); v != nil {
+			zw := v.(*zlib.Writer)
+			zw.Reset(w)
+			return zw
 		}
-		return zw
 	}
-	zw := v.(*zlib.Writer)
-	zw.Reset(w)
+	zw, err := zlib.NewWriterLevel(w, level)
+	if err != nil {
+		return zlib.NewWriter(w)
+	}
 	return zw
 }
 
+func releaseZlibWriter(zw *zlib.Writer, level int) {
+	if p := zlibWriterPoolOf(level); p != nil {
+		p.Put(zw)
+	}
+}
+
+// Now 签发票据时获取当前时间的方法，测试时可替换以固定签发时间
+// Now returns the issue time used when generating sigs. It can be replaced to pin the issue time in tests.
+var Now = time.Now
+
 // DefaultCompressionLevel is the default compression level.
 // Default is zlib.NoCompression.
 // It can be set to any valid compression level to balance speed and size.
@@ -0,0 +1,247 @@
+package sign
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testSdkAppID = 1400000000
+	testKey      = "5bd2850fff3ecb11d7c805251c51ee463a25727bddc2385f3fa8bfee1bb93b5e"
+	testUserID   = "test1"
+	testExpire   = 86400
+)
+
+func benchUserIDs(n int) []string {
+	userids := make([]string, n)
+	for i := range userids {
+		userids[i] = "user_" + strconv.Itoa(i)
+	}
+	return userids
+}
+
+func BenchmarkGenUserSigLoop(b *testing.B) {
+	userids := benchUserIDs(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, userid := range userids {
+			if _, err := GenUserSig(testSdkAppID, testKey, userid, testExpire); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGenUserSigBatch(b *testing.B) {
+	userids := benchUserIDs(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := GenUserSigBatch(testSdkAppID, testKey, userids, testExpire); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestValidateUserID(t *testing.T) {
+	tests := []struct {
+		userid string
+		valid  bool
+	}{
+		{"", false},
+		{"test1", true},
+		{"Test_user-01", true},
+		{"abcdefghijklmnopqrstuvwxyz012345", true},
+		{"abcdefghijklmnopqrstuvwxyz0123456", false},
+		{"test user", false},
+		{"test@user", false},
+		{"用户", false},
+		{"用户用户用户用户用户用户用户用户用户用户用户", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateUserID(tt.userid)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateUserID(%q) = %v, want nil", tt.userid, err)
+		}
+		if !tt.valid && err != ErrInvalidUserID {
+			t.Errorf("ValidateUserID(%q) = %v, want %v", tt.userid, err, ErrInvalidUserID)
+		}
+	}
+}
+
+func TestGenUserSigInvalidUserID(t *testing.T) {
+	if _, err := GenUserSig(testSdkAppID, testKey, "", testExpire); err != ErrInvalidUserID {
+		t.Fatalf("GenUserSig with empty userid = %v, want %v", err, ErrInvalidUserID)
+	}
+}
+
+func TestGenUserSigWithFixedNow(t *testing.T) {
+	issuedAt := time.Unix(1600000000, 0)
+	Now = func() time.Time { return issuedAt }
+	defer func() { Now = time.Now }()
+
+	usersig, err := GenUserSig(testSdkAppID, testKey, testUserID, testExpire)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.Time != issuedAt.Unix() || sig.Expire != testExpire {
+		t.Fatalf("got TLS.time=%d TLS.expire=%d, want %d and %d", sig.Time, sig.Expire, issuedAt.Unix(), testExpire)
+	}
+}
+
+func TestVerifyUserSigMalformed(t *testing.T) {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write([]byte(`{"TLS.ver":"2.0","TLS.identifier":`))
+	w.Close()
+
+	usersigs := []string{
+		"not a usersig",
+		base64url.EncodeToString([]byte("garbage")),
+		base64url.EncodeToString(b.Bytes()),
+	}
+
+	for _, usersig := range usersigs {
+		err := VerifyUserSig(testSdkAppID, testKey, testUserID, usersig, time.Now())
+		if !errors.Is(err, ErrMalformedUserSig) {
+			t.Errorf("VerifyUserSig(%q) = %v, want %v", usersig, err, ErrMalformedUserSig)
+		}
+	}
+}
+
+func encodeUserSig(t *testing.T, sig userSig) string {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	if err := json.NewEncoder(w).Encode(sig); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64url.EncodeToString(b.Bytes())
+}
+
+func TestVerifyUserSigWrongSig(t *testing.T) {
+	usersig, err := GenUserSig(testSdkAppID, testKey, testUserID, testExpire)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig.Sig[len(sig.Sig)-1] ^= 0xFF
+
+	err = VerifyUserSig(testSdkAppID, testKey, testUserID, encodeUserSig(t, sig), time.Now())
+	if err != ErrSigNotMatch {
+		t.Fatalf("VerifyUserSig with tampered sig = %v, want %v", err, ErrSigNotMatch)
+	}
+}
+
+func TestParsePrivateMapKeyBuf(t *testing.T) {
+	buf, err := genUserBuf(testUserID, testSdkAppID, 0, testExpire, 42, 0, "room-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := ParsePrivateMapKeyBuf(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != 1 || info.Account != testUserID || info.SdkAppID != testSdkAppID || info.RoomStr != "room-1" || info.PrivilegeMap != 42 {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	if buf, err = genUserBuf(testUserID, testSdkAppID, 1234, testExpire, 255, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = ParsePrivateMapKeyBuf(buf); err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != 0 || info.RoomID != 1234 || info.PrivilegeMap != 255 {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	for i := 0; i < len(buf); i++ {
+		if _, err = ParsePrivateMapKeyBuf(buf[:i]); err != ErrMalformedUserBuf {
+			t.Fatalf("ParsePrivateMapKeyBuf(buf[:%d]) = %v, want %v", i, err, ErrMalformedUserBuf)
+		}
+	}
+}
+
+func TestGenPrivateMapKeyRoomStrTooLong(t *testing.T) {
+	roomStr := strings.Repeat("r", maxUserBufFieldLength+1)
+	if _, err := GenPrivateMapKeyWithStringRoomID(testSdkAppID, testKey, testUserID, testExpire, roomStr, 255); err != ErrUserBufFieldTooLong {
+		t.Fatalf("GenPrivateMapKeyWithStringRoomID with oversized roomstr = %v, want %v", err, ErrUserBufFieldTooLong)
+	}
+}
+
+func TestGenUserSigWithOptions(t *testing.T) {
+	for _, level := range []int{zlib.NoCompression, zlib.BestSpeed, zlib.BestCompression} {
+		usersig, err := GenUserSigWithOptions(testSdkAppID, testKey, testUserID, testExpire, SigOptions{CompressionLevel: level})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = VerifyUserSig(testSdkAppID, testKey, testUserID, usersig, time.Now()); err != nil {
+			t.Fatalf("VerifyUserSig with compression level %d = %v", level, err)
+		}
+	}
+}
+
+func BenchmarkGenUserSig(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := GenUserSig(testSdkAppID, testKey, testUserID, testExpire); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestVerifyAndExtract(t *testing.T) {
+	privateMapKey, err := GenPrivateMapKey(testSdkAppID, testKey, testUserID, testExpire, 1234, 255)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userbuf, err := VerifyAndExtract(testSdkAppID, testKey, testUserID, privateMapKey, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := ParsePrivateMapKeyBuf(userbuf); err != nil || info.RoomID != 1234 {
+		t.Fatalf("ParsePrivateMapKeyBuf = %+v, %v", info, err)
+	}
+
+	usersig, err := GenUserSig(testSdkAppID, testKey, testUserID, testExpire)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userbuf, err = VerifyAndExtract(testSdkAppID, testKey, testUserID, usersig, time.Now()); err != nil || userbuf != nil {
+		t.Fatalf("VerifyAndExtract without userbuf = %v, %v", userbuf, err)
+	}
+}
+
+func TestGenUserSigNonPositiveExpire(t *testing.T) {
+	for _, expire := range []int{0, -1} {
+		if _, err := GenUserSig(testSdkAppID, testKey, testUserID, expire); err != ErrNonPositiveExpire {
+			t.Errorf("GenUserSig with expire %d = %v, want %v", expire, err, ErrNonPositiveExpire)
+		}
+	}
+
+	if _, err := GenUserSigWithOptions(testSdkAppID, testKey, testUserID, 0, SigOptions{AllowZeroExpire: true}); err != nil {
+		t.Errorf("GenUserSigWithOptions with AllowZeroExpire = %v, want nil", err)
+	}
+	if _, err := GenUserSigWithOptions(testSdkAppID, testKey, testUserID, -1, SigOptions{AllowZeroExpire: true}); err != ErrNonPositiveExpire {
+		t.Errorf("GenUserSigWithOptions with negative expire = %v, want %v", err, ErrNonPositiveExpire)
+	}
+}
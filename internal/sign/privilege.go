@@ -0,0 +1,57 @@
+package sign
+
+// Privilege PrivateMapKey 权限位
+// Privilege PrivateMapKey permission bit
+type Privilege uint32
+
+const (
+	PrivilegeCreateRoom      Privilege = 1 << iota // 创建房间的权限
+	PrivilegeEnterRoom                             // 加入房间的权限
+	PrivilegeSendAudio                             // 发送语音的权限
+	PrivilegeReceiveAudio                          // 接收语音的权限
+	PrivilegeSendVideo                             // 发送视频的权限
+	PrivilegeReceiveVideo                          // 接收视频的权限
+	PrivilegeSendSubVideo                          // 发送辅路（也就是屏幕分享）视频的权限
+	PrivilegeReceiveSubVideo                       // 接收辅路（也就是屏幕分享）视频的权限
+	PrivilegeAll             Privilege = 0xFF      // 所有功能权限
+)
+
+// Privileges 权限位集合
+// Privileges A set of PrivateMapKey permission bits
+type Privileges struct {
+	mask uint32
+}
+
+// Add 添加权限
+// Add Return a copy of p with the given privileges added
+func (p Privileges) Add(privileges ...Privilege) Privileges {
+	for _, privilege := range privileges {
+		p.mask |= uint32(privilege)
+	}
+	return p
+}
+
+// Or 合并权限集合
+// Or Return the union of p and other
+func (p Privileges) Or(other Privileges) Privileges {
+	p.mask |= other.mask
+	return p
+}
+
+// Has 是否拥有指定权限
+// Has Report whether p contains privilege
+func (p Privileges) Has(privilege Privilege) bool {
+	return p.mask&uint32(privilege) == uint32(privilege)
+}
+
+// Mask 获取权限位
+// Mask Return the raw privilegeMap
+func (p Privileges) Mask() uint32 {
+	return p.mask
+}
+
+// GenPrivateMapKeyWithPrivileges 使用权限位集合签发数字房间号的PrivateMapKey
+// GenPrivateMapKeyWithPrivileges Issue PrivateMapKey for a numeric roomid with typed privileges
+func GenPrivateMapKeyWithPrivileges(sdkappid int, key string, userid string, expire int, roomid uint32, privileges Privileges) (string, error) {
+	return GenPrivateMapKey(sdkappid, key, userid, expire, roomid, privileges.Mask())
+}
@@ -0,0 +1,64 @@
+package sign
+
+import (
+	"time"
+)
+
+// Signer 绑定了 sdkappid 和 key 的签名器，避免在每次签发时传递密钥
+// Signer issues and verifies sigs for a fixed sdkappid and key
+type Signer struct {
+	sdkappid int
+	key      []byte
+}
+
+// NewSigner 创建签名器
+// NewSigner Create a signer bound to sdkappid and key
+func NewSigner(sdkappid int, key string) *Signer {
+	return &Signer{sdkappid: sdkappid, key: []byte(key)}
+}
+
+// UserSig 签发UserSig
+// UserSig Issue UserSig that expires after expire
+func (s *Signer) UserSig(userid string, expire time.Duration) (string, error) {
+	return genSigWithHash(newHMAC(s.key), s.sdkappid, userid, int(expire/time.Second), nil, defaultSigOptions())
+}
+
+// PrivateMapKey 签发数字房间号的PrivateMapKey
+// PrivateMapKey Issue PrivateMapKey for a numeric roomid
+func (s *Signer) PrivateMapKey(userid string, expire time.Duration, roomid uint32, privilegeMap uint32) (string, error) {
+	userbuf, err := genUserBuf(userid, s.sdkappid, roomid, int(expire/time.Second), privilegeMap, 0, "")
+	if err != nil {
+		return "", err
+	}
+	return genSigWithHash(newHMAC(s.key), s.sdkappid, userid, int(expire/time.Second), userbuf, defaultSigOptions())
+}
+
+// PrivateMapKeyWithStringRoomID 签发字符串房间号的PrivateMapKey
+// PrivateMapKeyWithStringRoomID Issue PrivateMapKey for a string roomid
+func (s *Signer) PrivateMapKeyWithStringRoomID(userid string, expire time.Duration, roomStr string, privilegeMap uint32) (string, error) {
+	userbuf, err := genUserBuf(userid, s.sdkappid, 0, int(expire/time.Second), privilegeMap, 0, roomStr)
+	if err != nil {
+		return "", err
+	}
+	return genSigWithHash(newHMAC(s.key), s.sdkappid, userid, int(expire/time.Second), userbuf, defaultSigOptions())
+}
+
+// Verify 检验UserSig在now时间点时是否有效
+// Verify Check if UserSig is valid at now time
+func (s *Signer) Verify(userid string, usersig string, now time.Time) error {
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		return err
+	}
+	return sig.verify(uint64(s.sdkappid), s.key, userid, now, nil)
+}
+
+// VerifyWithBuf 检验带UserBuf的UserSig在now时间点是否有效
+// VerifyWithBuf Check if UserSig with UserBuf is valid at now
+func (s *Signer) VerifyWithBuf(userid string, usersig string, now time.Time, userbuf []byte) error {
+	sig, err := newUserSig(usersig)
+	if err != nil {
+		return err
+	}
+	return sig.verify(uint64(s.sdkappid), s.key, userid, now, userbuf)
+}
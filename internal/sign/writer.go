@@ -0,0 +1,96 @@
+package sign
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// SigFormat 批量输出UserSig的格式
+// SigFormat Output format of WriteUserSigs
+type SigFormat int
+
+const (
+	SigFormatCSV       SigFormat = iota // 每行一条 userid,usersig
+	SigFormatJSONLines                  // 每行一个 {"userid":"...","usersig":"..."}
+)
+
+// writeUserSigsFlushRows 每写入多少行刷新一次缓冲区
+const writeUserSigsFlushRows = 1000
+
+var ErrUnknownSigFormat = errors.New("unknown sig format")
+
+// WriteUserSigs 批量签发UserSig并逐行写入w，所有userid共用同一个HMAC实例
+// 签发失败的userid不会写入w，并通过 BatchError 返回；写入w失败时立即返回
+// WriteUserSigs Issue UserSig for each userid and stream "userid,usersig" rows to w while reusing a single HMAC instance.
+// Userids that fail are skipped and reported through a BatchError; write errors abort immediately.
+func WriteUserSigs(w io.Writer, sdkappid int, key string, userids []string, expire int, format SigFormat) error {
+	var (
+		h    = newHMAC([]byte(key))
+		errs BatchError
+		bw   = bufio.NewWriter(w)
+		cw   *csv.Writer
+		jw   *json.Encoder
+	)
+
+	switch format {
+	case SigFormatCSV:
+		cw = csv.NewWriter(bw)
+	case SigFormatJSONLines:
+		jw = json.NewEncoder(bw)
+	default:
+		return ErrUnknownSigFormat
+	}
+
+	rows := 0
+	for _, userid := range userids {
+		sig, err := genSigWithHash(h, sdkappid, userid, expire, nil, defaultSigOptions())
+		if err != nil {
+			if errs == nil {
+				errs = make(BatchError)
+			}
+			errs[userid] = err
+			continue
+		}
+
+		if cw != nil {
+			err = cw.Write([]string{userid, sig})
+		} else {
+			err = jw.Encode(userSigRow{UserID: userid, UserSig: sig})
+		}
+		if err != nil {
+			return err
+		}
+
+		if rows++; rows%writeUserSigsFlushRows == 0 {
+			if err = flushUserSigs(bw, cw); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flushUserSigs(bw, cw); err != nil {
+		return err
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+type userSigRow struct {
+	UserID  string `json:"userid"`
+	UserSig string `json:"usersig"`
+}
+
+func flushUserSigs(bw *bufio.Writer, cw *csv.Writer) error {
+	if cw != nil {
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,59 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/27 12:54 下午
+ * @Desc: 消息体解析
+ */
+
+package types
+
+import "encoding/json"
+
+// msgContents 消息元素类型对应的消息内容构造函数
+// 消息元素类型与 enum 包中的定义保持一致（enum 包依赖本包，此处无法直接引用）
+var msgContents = map[string]func() interface{}{
+	"TIMTextElem":      func() interface{} { return &MsgTextContent{} },
+	"TIMLocationElem":  func() interface{} { return &MsgLocationContent{} },
+	"TIMFaceElem":      func() interface{} { return &MsgFaceContent{} },
+	"TIMCustomElem":    func() interface{} { return &MsgCustomContent{} },
+	"TIMSoundElem":     func() interface{} { return &MsgSoundContent{} },
+	"TIMImageElem":     func() interface{} { return &MsgImageContent{} },
+	"TIMFileElem":      func() interface{} { return &MsgFileContent{} },
+	"TIMVideoFileElem": func() interface{} { return &MsgVideoContent{} },
+}
+
+// UnmarshalJSON 解析消息体
+// 根据消息元素类型将消息内容解析为对应的消息内容指针（如 *MsgTextContent），未知的消息元素类型解析为 map[string]interface{}
+func (b *MsgBody) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		MsgType    string          `json:"MsgType"`
+		MsgContent json.RawMessage `json:"MsgContent"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	b.MsgType, b.MsgContent = raw.MsgType, nil
+
+	if len(raw.MsgContent) == 0 || string(raw.MsgContent) == "null" {
+		return nil
+	}
+
+	if fn, ok := msgContents[raw.MsgType]; ok {
+		content := fn()
+		if err := json.Unmarshal(raw.MsgContent, content); err != nil {
+			return err
+		}
+		b.MsgContent = content
+		return nil
+	}
+
+	var content map[string]interface{}
+	if err := json.Unmarshal(raw.MsgContent, &content); err != nil {
+		return err
+	}
+	b.MsgContent = content
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMsgBodyRoundTrip(t *testing.T) {
+	bodies := []MsgBody{
+		{MsgType: "TIMTextElem", MsgContent: &MsgTextContent{Text: "hello"}},
+		{MsgType: "TIMLocationElem", MsgContent: &MsgLocationContent{Desc: "office", Latitude: 22.5431, Longitude: 113.9347}},
+		{MsgType: "TIMFaceElem", MsgContent: &MsgFaceContent{Index: 1, Data: "smile"}},
+		{MsgType: "TIMCustomElem", MsgContent: &MsgCustomContent{Desc: "notify", Data: "{\"k\":\"v\"}", Ext: "ext", Sound: "dingdong.aiff"}},
+		{MsgType: "TIMSoundElem", MsgContent: &MsgSoundContent{UUID: "sound-uuid", Url: "https://example.com/a.mp3", Size: 1024, Second: 3, DownloadFlag: 2}},
+		{MsgType: "TIMImageElem", MsgContent: &MsgImageContent{UUID: "image-uuid", ImageFormat: 1, ImageInfos: []*ImageInfo{
+			{Type: 1, Size: 2048, Width: 640, Height: 480, Url: "https://example.com/a.jpg"},
+			{Type: 3, Size: 256, Width: 198, Height: 148, Url: "https://example.com/a_thumb.jpg"},
+		}}},
+		{MsgType: "TIMFileElem", MsgContent: &MsgFileContent{Url: "https://example.com/a.txt", UUID: "file-uuid", FileSize: 512, FileName: "a.txt", DownloadFlag: 2}},
+		{MsgType: "TIMVideoFileElem", MsgContent: &MsgVideoContent{
+			VideoUUID: "video-uuid", VideoUrl: "https://example.com/a.mp4", VideoSize: 4096, VideoSecond: 10, VideoFormat: "mp4", VideoDownloadFlag: 2,
+			ThumbUrl: "https://example.com/a.jpg", ThumbUUID: "thumb-uuid", ThumbSize: 256, ThumbWidth: 198, ThumbHeight: 148, ThumbFormat: "JPG", ThumbDownloadFlag: 2,
+		}},
+	}
+
+	for _, body := range bodies {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", body.MsgType, err)
+		}
+
+		var decoded MsgBody
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: unmarshal failed: %v", body.MsgType, err)
+		}
+
+		if !reflect.DeepEqual(decoded, body) {
+			t.Errorf("%s: expected %+v, got %+v", body.MsgType, body.MsgContent, decoded.MsgContent)
+		}
+	}
+}
+
+func TestMsgBodyUnknownType(t *testing.T) {
+	var body MsgBody
+	if err := json.Unmarshal([]byte(`{"MsgType":"TIMUnknownElem","MsgContent":{"Foo":"bar","Count":2}}`), &body); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	expected := map[string]interface{}{"Foo": "bar", "Count": float64(2)}
+	if !reflect.DeepEqual(body.MsgContent, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, body.MsgContent)
+	}
+}
+
+func TestMsgBodyNullContent(t *testing.T) {
+	for _, data := range []string{
+		`{"MsgType":"TIMTextElem","MsgContent":null}`,
+		`{"MsgType":"TIMTextElem"}`,
+	} {
+		body := MsgBody{MsgContent: "stale"}
+		if err := json.Unmarshal([]byte(data), &body); err != nil {
+			t.Fatalf("%s: unmarshal failed: %v", data, err)
+		}
+
+		if body.MsgType != "TIMTextElem" || body.MsgContent != nil {
+			t.Errorf("%s: expected TIMTextElem with nil content, got %+v", data, body)
+		}
+	}
+}
+
+func TestMsgBodyInvalidContent(t *testing.T) {
+	var body MsgBody
+	if err := json.Unmarshal([]byte(`{"MsgType":"TIMTextElem","MsgContent":{"Text":1}}`), &body); err == nil {
+		t.Fatal("expected error for mismatched content")
+	}
+}
@@ -33,6 +33,7 @@ type (
 
 	// ApnsInfo IOS离线推送消息
 	ApnsInfo struct {
+		Sound          string `json:"Sound,omitempty"`          // （选填）iOS 离线推送声音文件名。
 		BadgeMode      int    `json:"BadgeMode,omitempty"`      // （选填）这个字段缺省或者为0表示需要计数，为1表示本条消息不需要计数，即右上角图标数字不增加。
 		Title          string `json:"Title,omitempty"`          // （选填）该字段用于标识 APNs 推送的标题，若填写则会覆盖最上层 Title。
 		SubTitle       string `json:"SubTitle,omitempty"`       // （选填）该字段用于标识 APNs 推送的子标题。
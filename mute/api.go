@@ -8,7 +8,10 @@
 package mute
 
 import (
+	"time"
+
 	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/types"
 )
 
@@ -22,6 +25,7 @@ type API interface {
 	// SetNoSpeaking 设置全局禁言
 	// 设置帐号的单聊消息全局禁言。
 	// 设置帐号的群组消息全局禁言。
+	// 禁言时间单位为秒，0（MuteCancel）表示取消禁言，4294967295（MuteForever）表示永久禁言，传入nil表示不修改对应的禁言设置。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/4230
 	SetNoSpeaking(userId string, privateMuteTime, groupMuteTime *uint) (err error)
@@ -29,6 +33,7 @@ type API interface {
 	// GetNoSpeaking 查询全局禁言
 	// 查询帐号的单聊消息全局禁言。
 	// 查询帐号的群组消息全局禁言。
+	// 返回结果同时提供转换后的禁言时长，永久禁言时为 MutePermanent。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/4229
 	GetNoSpeaking(userId string) (ret *GetNoSpeakingRet, err error)
@@ -45,9 +50,20 @@ func NewAPI(client core.Client) API {
 // SetNoSpeaking 设置全局禁言
 // 设置帐号的单聊消息全局禁言。
 // 设置帐号的群组消息全局禁言。
+// 禁言时间单位为秒，0（MuteCancel）表示取消禁言，4294967295（MuteForever）表示永久禁言，传入nil表示不修改对应的禁言设置。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/4230
 func (a *api) SetNoSpeaking(userId string, privateMuteTime, groupMuteTime *uint) (err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	if privateMuteTime == nil && groupMuteTime == nil {
+		err = core.NewError(enum.InvalidParamsCode, "the mute time is not set")
+		return
+	}
+
 	req := &setNoSpeakingReq{
 		UserId:          userId,
 		PrivateMuteTime: privateMuteTime,
@@ -64,9 +80,15 @@ func (a *api) SetNoSpeaking(userId string, privateMuteTime, groupMuteTime *uint)
 // GetNoSpeaking 查询全局禁言
 // 查询帐号的单聊消息全局禁言。
 // 查询帐号的群组消息全局禁言。
+// 返回结果同时提供转换后的禁言时长，永久禁言时为 MutePermanent。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/4229
 func (a *api) GetNoSpeaking(userId string) (ret *GetNoSpeakingRet, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	req := &getNoSpeakingReq{UserId: userId}
 	resp := &getNoSpeakingResp{}
 
@@ -75,9 +97,20 @@ func (a *api) GetNoSpeaking(userId string) (ret *GetNoSpeakingRet, err error) {
 	}
 
 	ret = &GetNoSpeakingRet{
-		PrivateMuteTime: resp.PrivateMuteTime,
-		GroupMuteTime:   resp.GroupMuteTime,
+		PrivateMuteTime:     resp.PrivateMuteTime,
+		GroupMuteTime:       resp.GroupMuteTime,
+		PrivateMuteDuration: toMuteDuration(resp.PrivateMuteTime),
+		GroupMuteDuration:   toMuteDuration(resp.GroupMuteTime),
 	}
 
 	return
 }
+
+// toMuteDuration 将禁言秒数转换为禁言时长
+func toMuteDuration(seconds uint) time.Duration {
+	if seconds == MuteForever {
+		return MutePermanent
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,18 @@
+/**
+ * @Author: fuxiao
+ * @Email: 576101059@qq.com
+ * @Date: 2021/8/30 2:41 上午
+ * @Desc: 全局禁言枚举参数
+ */
+
+package mute
+
+import "time"
+
+const (
+	MuteCancel  uint = 0          // 取消禁言
+	MuteForever uint = 4294967295 // 永久禁言
+)
+
+// MutePermanent 永久禁言时返回的禁言时长
+const MutePermanent time.Duration = 1<<63 - 1
@@ -7,7 +7,11 @@
 
 package mute
 
-import "github.com/dobyte/tencent-im/internal/types"
+import (
+	"time"
+
+	"github.com/dobyte/tencent-im/internal/types"
+)
 
 type (
 	// 设置全局禁言（请求）
@@ -31,7 +35,9 @@ type (
 
 	// GetNoSpeakingRet 获取全局禁言（返回）
 	GetNoSpeakingRet struct {
-		PrivateMuteTime uint // 单聊消息禁言时长，单位为秒，非负整数。等于 0 代表没有被设置禁言；等于最大值4294967295（十六进制 0xFFFFFFFF）代表被设置永久禁言；其它代表该帐号禁言时长，如果等于3600表示该帐号被禁言一小时
-		GroupMuteTime   uint // 群组消息禁言时长，单位为秒，非负整数。等于0代表没有被设置禁言；等于最大值4294967295（十六进制 0xFFFFFFFF）代表被设置永久禁言；其它代表该帐号禁言时长，如果等于3600表示该帐号被禁言一小时
+		PrivateMuteTime     uint          // 单聊消息禁言时长，单位为秒，非负整数。等于 0 代表没有被设置禁言；等于最大值4294967295（十六进制 0xFFFFFFFF）代表被设置永久禁言；其它代表该帐号禁言时长，如果等于3600表示该帐号被禁言一小时
+		GroupMuteTime       uint          // 群组消息禁言时长，单位为秒，非负整数。等于0代表没有被设置禁言；等于最大值4294967295（十六进制 0xFFFFFFFF）代表被设置永久禁言；其它代表该帐号禁言时长，如果等于3600表示该帐号被禁言一小时
+		PrivateMuteDuration time.Duration // 单聊消息禁言时长，等于0代表没有被设置禁言；等于MutePermanent代表被设置永久禁言
+		GroupMuteDuration   time.Duration // 群组消息禁言时长，等于0代表没有被设置禁言；等于MutePermanent代表被设置永久禁言
 	}
 )
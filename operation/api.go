@@ -8,9 +8,22 @@
 package operation
 
 import (
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
 )
 
 const (
@@ -20,6 +33,16 @@ const (
 	commandGetAppInfo  = "getappinfo"
 	commandGetHistory  = "get_history"
 	commandGetIPList   = "GetIPList"
+
+	maxOperationDataDays = 30 // 最多可拉取的运营数据天数
+)
+
+var (
+	errNotSetHistoryFile = errors.New("the history file is not set")
+	errInvalidChatType   = errors.New("invalid chat type")
+
+	// ErrHistoryFileChecksum 消息记录文件校验失败
+	ErrHistoryFileChecksum = errors.New("history file checksum mismatch")
 )
 
 type API interface {
@@ -29,15 +52,33 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/4193
 	GetOperationData(fields ...FieldType) (data []*OperationData, err error)
 
+	// GetDailyStats 拉取每日运营数据
+	// 本方法拓展于“拉取运营数据（GetOperationData）”方法。
+	// 按日期倒序返回最近 days 天（最多30天）的运营数据，并将数值字段解析为整数。
+	// fields 为空时默认拉取所有字段，未拉取的字段值为零值。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/4193
+	GetDailyStats(days int, fields ...FieldType) (stats []*DailyStat, err error)
+
 	// GetHistoryData 下载最近消息记录
 	// App 管理员可以通过该接口获取 App 中最近7天中某天某小时的所有单发或群组消息记录的下载地址
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1650
 	GetHistoryData(chatType ChatType, msgTime time.Time) (files []*HistoryFile, err error)
 
+	// DownloadHistoryFile 下载消息记录文件
+	// 本方法配合“下载最近消息记录（GetHistoryData）”方法使用。
+	// 下载消息记录文件并解压，将解压后的内容写入 w，下载完成后会校验压缩文件的 MD5，校验失败时返回 ErrHistoryFileChecksum。
+	// 请求通过客户端配置的HTTP客户端发送，并使用客户端绑定的上下文（见 IM.WithContext）；返回错误时 w 中可能已写入部分或未经校验的数据，请丢弃。
+	// 下载地址过期时请通过“下载最近消息记录（GetHistoryData）”方法重新获取。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1650
+	DownloadHistoryFile(file *HistoryFile, w io.Writer) (err error)
+
 	// GetIPList 获取服务器IP地址
 	// 基于安全等考虑，您可能需要获知服务器的 IP 地址列表，以便进行相关限制。
 	// App 管理员可以通过该接口获得 SDK、第三方回调所使用到的服务器 IP 地址列表或 IP 网段信息。
+	// 返回的IP地址及IP网段均会转换为标准的CIDR格式（如 1.2.3.4/32），无法解析的条目将原样返回。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/45438
 	GetIPList() (ips []string, err error)
@@ -68,11 +109,63 @@ func (a *api) GetOperationData(fields ...FieldType) (data []*OperationData, err
 	return
 }
 
+// GetDailyStats 拉取每日运营数据
+// 本方法拓展于“拉取运营数据（GetOperationData）”方法。
+// 按日期倒序返回最近 days 天（最多30天）的运营数据，并将数值字段解析为整数。
+// fields 为空时默认拉取所有字段，未拉取的字段值为零值。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/4193
+func (a *api) GetDailyStats(days int, fields ...FieldType) (stats []*DailyStat, err error) {
+	if days <= 0 || days > maxOperationDataDays {
+		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the days must be between 1 and %d", maxOperationDataDays))
+		return
+	}
+
+	if len(fields) > 0 {
+		hasDate := false
+		for _, field := range fields {
+			if field == FieldTypeDate {
+				hasDate = true
+				break
+			}
+		}
+
+		if !hasDate {
+			fields = append(fields[:len(fields):len(fields)], FieldTypeDate)
+		}
+	}
+
+	var data []*OperationData
+	if data, err = a.GetOperationData(fields...); err != nil {
+		return
+	}
+
+	stats = make([]*DailyStat, 0, len(data))
+	for _, item := range data {
+		stats = append(stats, newDailyStat(item))
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Date.After(stats[j].Date)
+	})
+
+	if len(stats) > days {
+		stats = stats[:days]
+	}
+
+	return
+}
+
 // GetHistoryData 下载最近消息记录
 // App 管理员可以通过该接口获取 App 中最近7天中某天某小时的所有单发或群组消息记录的下载地址
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1650
 func (a *api) GetHistoryData(chatType ChatType, msgTime time.Time) (files []*HistoryFile, err error) {
+	if chatType != ChatTypeC2C && chatType != ChatTypeGroup {
+		err = errInvalidChatType
+		return
+	}
+
 	req := &getHistoryDataReq{ChatType: chatType, MsgTime: msgTime.Format("2006010215")}
 	resp := &getHistoryDataResp{}
 
@@ -85,9 +178,65 @@ func (a *api) GetHistoryData(chatType ChatType, msgTime time.Time) (files []*His
 	return
 }
 
+// DownloadHistoryFile 下载消息记录文件
+// 本方法配合“下载最近消息记录（GetHistoryData）”方法使用。
+// 下载消息记录文件并解压，将解压后的内容写入 w，下载完成后会校验压缩文件的 MD5，校验失败时返回 ErrHistoryFileChecksum。
+// 请求通过客户端配置的HTTP客户端发送，并使用客户端绑定的上下文（见 IM.WithContext）；返回错误时 w 中可能已写入部分或未经校验的数据，请丢弃。
+// 下载地址过期时请通过“下载最近消息记录（GetHistoryData）”方法重新获取。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1650
+func (a *api) DownloadHistoryFile(file *HistoryFile, w io.Writer) (err error) {
+	if file == nil || file.URL == "" {
+		err = errNotSetHistoryFile
+		return
+	}
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(a.client.Context(), http.MethodGet, file.URL, nil); err != nil {
+		return
+	}
+
+	var resp *http.Response
+	if resp, err = a.client.HTTPClient().Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("download history file failed: %s", resp.Status)
+		return
+	}
+
+	hash := md5.New()
+	body := io.TeeReader(resp.Body, hash)
+
+	var reader *gzip.Reader
+	if reader, err = gzip.NewReader(body); err != nil {
+		return
+	}
+	defer reader.Close()
+
+	if _, err = io.Copy(w, reader); err != nil {
+		return
+	}
+
+	// 读取剩余的数据，确保 MD5 覆盖完整的压缩文件
+	if _, err = io.Copy(ioutil.Discard, body); err != nil {
+		return
+	}
+
+	if file.FileMD5 != "" && !strings.EqualFold(hex.EncodeToString(hash.Sum(nil)), file.FileMD5) {
+		err = ErrHistoryFileChecksum
+		return
+	}
+
+	return
+}
+
 // GetIPList 获取服务器IP地址
 // 基于安全等考虑，您可能需要获知服务器的 IP 地址列表，以便进行相关限制。
 // App 管理员可以通过该接口获得 SDK、第三方回调所使用到的服务器 IP 地址列表或 IP 网段信息。
+// 返回的IP地址及IP网段均会转换为标准的CIDR格式（如 1.2.3.4/32），无法解析的条目将原样返回。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/45438
 func (a *api) GetIPList() (ips []string, err error) {
@@ -98,7 +247,74 @@ func (a *api) GetIPList() (ips []string, err error) {
 		return
 	}
 
-	ips = resp.IPList
+	ips = make([]string, 0, len(resp.IPList))
+	for _, ip := range resp.IPList {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			ips = append(ips, normalizeCIDR(ip))
+		}
+	}
 
 	return
 }
+
+// newDailyStat 解析运营数据
+func newDailyStat(d *OperationData) *DailyStat {
+	date, _ := time.ParseInLocation("20060102", d.Date, time.Local)
+
+	return &DailyStat{
+		Date:                 date,
+		AppId:                d.AppId,
+		AppName:              d.AppName,
+		Company:              d.Company,
+		ActiveUserNum:        parseInt(d.ActiveUserNum),
+		RegistUserNumOneDay:  parseInt(d.RegistUserNumOneDay),
+		RegistUserNumTotal:   parseInt(d.RegistUserNumTotal),
+		LoginTimes:           parseInt(d.LoginTimes),
+		LoginUserNum:         parseInt(d.LoginUserNum),
+		UpMsgNum:             parseInt(d.UpMsgNum),
+		DownMsgNum:           parseInt(d.DownMsgNum),
+		SendMsgUserNum:       parseInt(d.SendMsgUserNum),
+		APNSMsgNum:           parseInt(d.APNSMsgNum),
+		C2CUpMsgNum:          parseInt(d.C2CUpMsgNum),
+		C2CSendMsgUserNum:    parseInt(d.C2CSendMsgUserNum),
+		C2CAPNSMsgNum:        parseInt(d.C2CAPNSMsgNum),
+		C2CDownMsgNum:        parseInt(d.C2CDownMsgNum),
+		MaxOnlineNum:         parseInt(d.MaxOnlineNum),
+		ChainDecrease:        parseInt(d.ChainDecrease),
+		ChainIncrease:        parseInt(d.ChainIncrease),
+		GroupUpMsgNum:        parseInt(d.GroupUpMsgNum),
+		GroupDownMsgNum:      parseInt(d.GroupDownMsgNum),
+		GroupSendMsgUserNum:  parseInt(d.GroupSendMsgUserNum),
+		GroupAPNSMsgNum:      parseInt(d.GroupAPNSMsgNum),
+		GroupSendMsgGroupNum: parseInt(d.GroupSendMsgGroupNum),
+		GroupJoinGroupTimes:  parseInt(d.GroupJoinGroupTimes),
+		GroupQuitGroupTimes:  parseInt(d.GroupQuitGroupTimes),
+		GroupNewGroupNum:     parseInt(d.GroupNewGroupNum),
+		GroupAllGroupNum:     parseInt(d.GroupAllGroupNum),
+		GroupDestroyGroupNum: parseInt(d.GroupDestroyGroupNum),
+		CallBackReq:          parseInt(d.CallBackReq),
+		CallBackRsp:          parseInt(d.CallBackRsp),
+	}
+}
+
+// parseInt 解析运营数据中的数值，无法解析时返回0
+func parseInt(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
+
+// normalizeCIDR 将IP地址或IP网段转换为标准的CIDR格式，无法解析时原样返回
+func normalizeCIDR(s string) string {
+	if _, ipNet, err := net.ParseCIDR(s); err == nil {
+		return ipNet.String()
+	}
+
+	if ip := net.ParseIP(s); ip != nil {
+		if ip.To4() != nil {
+			return ip.String() + "/32"
+		}
+		return ip.String() + "/128"
+	}
+
+	return s
+}
@@ -7,7 +7,11 @@
 
 package operation
 
-import "github.com/dobyte/tencent-im/internal/types"
+import (
+	"time"
+
+	"github.com/dobyte/tencent-im/internal/types"
+)
 
 type (
 	// 拉取运营数据（请求）
@@ -57,6 +61,42 @@ type (
 		Date                 string `json:"Date"`                 // 日期
 	}
 
+	// DailyStat 每日运营数据
+	DailyStat struct {
+		Date                 time.Time // 日期
+		AppId                string    // 应用AppID
+		AppName              string    // 应用名称
+		Company              string    // 所属客户名称
+		ActiveUserNum        int64     // 活跃用户数
+		RegistUserNumOneDay  int64     // 新增注册人数
+		RegistUserNumTotal   int64     // 累计注册人数
+		LoginTimes           int64     // 登录次数
+		LoginUserNum         int64     // 登录人数
+		UpMsgNum             int64     // 上行消息数
+		DownMsgNum           int64     // 下行消息数
+		SendMsgUserNum       int64     // 发消息人数
+		APNSMsgNum           int64     // APNs推送数
+		C2CUpMsgNum          int64     // 上行消息数（C2C）
+		C2CSendMsgUserNum    int64     // 发消息人数（C2C）
+		C2CAPNSMsgNum        int64     // APNs推送数（C2C）
+		C2CDownMsgNum        int64     // 下行消息数（C2C）
+		MaxOnlineNum         int64     // 最高在线人数
+		ChainDecrease        int64     // 关系链对数删除量
+		ChainIncrease        int64     // 关系链对数增加量
+		GroupUpMsgNum        int64     // 上行消息数（群）
+		GroupDownMsgNum      int64     // 下行消息数（群）
+		GroupSendMsgUserNum  int64     // 发消息人数（群）
+		GroupAPNSMsgNum      int64     // APNs推送数（群）
+		GroupSendMsgGroupNum int64     // 发消息群组数
+		GroupJoinGroupTimes  int64     // 入群总数
+		GroupQuitGroupTimes  int64     // 退群总数
+		GroupNewGroupNum     int64     // 新增群组数
+		GroupAllGroupNum     int64     // 累计群组数
+		GroupDestroyGroupNum int64     // 解散群个数
+		CallBackReq          int64     // 回调请求数
+		CallBackRsp          int64     // 回调应答数
+	}
+
 	// 获取历史数据（请求）
 	getHistoryDataReq struct {
 		ChatType ChatType `json:"ChatType"` // （必填）消息类型，C2C 表示单发消息 Group 表示群组消息
@@ -10,6 +10,7 @@ package private
 import (
 	"github.com/dobyte/tencent-im/internal/conv"
 	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/types"
 )
 
@@ -23,6 +24,8 @@ const (
 	commandSetMessageRead      = "admin_set_msg_read"
 	commandGetUnreadMessageNum = "get_c2c_unread_msg_num"
 	commandModifyMessage       = "modify_c2c_msg"
+
+	batchSendMessagesLimit = 500 // 批量发单聊消息接收方限制
 )
 
 type API interface {
@@ -36,7 +39,7 @@ type API interface {
 	SendMessage(message *Message) (ret *SendMessageRet, err error)
 
 	// SendMessages 批量发单聊消息
-	// 支持一次对最多500个用户进行单发消息。
+	// 支持一次对最多500个用户进行单发消息，超过500个接收方时自动分批发送。
 	// 与单发消息相比，该接口更适用于营销类消息、系统通知 tips 等时效性较强的消息。
 	// 管理员指定某一帐号向目标帐号批量发消息，接收方看到发送者不是管理员，而是管理员指定的帐号。
 	// 该接口不触发回调请求。
@@ -52,6 +55,7 @@ type API interface {
 	// 该接口不会触发回调。
 	// 该接口会根据 From_Account ， To_Account ，MsgSeq ， MsgRandom ， MsgTimeStamp 字段的值对导入的消息进行去重。仅当这五个字段的值都对应相同时，才判定消息是重复的，消息是否重复与消息内容本身无关。
 	// 重复导入的消息不会覆盖之前已导入的消息（即消息内容以首次导入的为准）。
+	// 导入消息的时间戳不能晚于当前时间。
 	// 单聊消息 MsgSeq 字段的作用及说明：该字段在发送消息时由用户自行指定，该值可以重复，非后台生成，非全局唯一。与群聊消息的 MsgSeq 字段不同，群聊消息的 MsgSeq 由后台生成，每个群都维护一个 MsgSeq，从1开始严格递增。单聊消息历史记录对同一个会话的消息先以时间戳排序，同秒内的消息再以 MsgSeq 排序。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/2568
@@ -91,18 +95,20 @@ type API interface {
 	// 若需要撤回由 REST API 单发 和 批量发 接口发出的单聊消息，需要记录这些接口回包里的 MsgKey 字段以进行撤回。
 	// 调用该接口撤回消息后，该条消息的离线、漫游存储，以及消息发送方和接收方的客户端的本地缓存都会被撤回。
 	// 该接口可撤回的单聊消息没有时间限制，即可以撤回任何时间的单聊消息。
+	// 待撤回的消息不存在时返回的错误满足 errors.Is(err, ErrMessageNotFound)。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/38980
 	RevokeMessage(fromUserId, toUserId, msgKey string) (err error)
 
 	// SetMessageRead 设置单聊消息已读
-	// 设置用户的某个单聊会话的消息全部已读。
+	// 设置用户的某个单聊会话的消息已读，未指定已读时间或已读时间为0时设置全部消息已读。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/50349
-	SetMessageRead(userId, peerUserId string) (err error)
+	SetMessageRead(userId, peerUserId string, msgReadTime ...int64) (err error)
 
 	// GetUnreadMessageNum 查询单聊未读消息计数
 	// App 后台可以通过该接口查询特定账号的单聊总未读数（包含所有的单聊会话）或者单个单聊会话的未读数。
+	// 未指定会话对端时仅返回总未读数，指定时在结果中按对端UserId返回各会话的未读数。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/56043
 	GetUnreadMessageNum(userId string, peerUserIds ...string) (ret *GetUnreadMessageNumRet, err error)
@@ -151,15 +157,16 @@ func (a *api) SendMessage(message *Message) (ret *SendMessageRet, err error) {
 	}
 
 	ret = &SendMessageRet{
-		MsgKey:  resp.MsgKey,
-		MsgTime: resp.MsgTime,
+		MsgKey:    resp.MsgKey,
+		MsgTime:   resp.MsgTime,
+		MsgRandom: req.MsgRandom,
 	}
 
 	return
 }
 
 // SendMessages 批量发单聊消息
-// 支持一次对最多500个用户进行单发消息。
+// 支持一次对最多500个用户进行单发消息，超过500个接收方时自动分批发送。
 // 与单发消息相比，该接口更适用于营销类消息、系统通知 tips 等时效性较强的消息。
 // 管理员指定某一帐号向目标帐号批量发消息，接收方看到发送者不是管理员，而是管理员指定的帐号。
 // 该接口不触发回调请求。
@@ -172,26 +179,49 @@ func (a *api) SendMessages(message *Message) (ret *SendMessagesRet, err error) {
 		return
 	}
 
-	req := &sendMessagesReq{}
-	req.FromUserId = message.GetSender()
-	req.ToUserIds = message.GetReceivers()
-	req.OfflinePushInfo = message.GetOfflinePushInfo()
-	req.CloudCustomData = conv.String(message.GetCustomData())
-	req.MsgSeq = message.GetSerialNo()
-	req.MsgBody = message.GetBody()
-	req.MsgRandom = message.GetRandom()
-	req.SendMsgControl = message.GetSendMsgControl()
-	req.SyncOtherMachine = message.GetSyncOtherMachine()
+	receivers := message.GetReceivers()
 
-	resp := &sendMessagesResp{}
+	ret = &SendMessagesRet{MsgKeys: make(map[string]string, len(receivers))}
 
-	if err = a.client.Post(service, commandSendMessages, req, resp); err != nil {
-		return
-	}
+	for i := 0; i < len(receivers); i += batchSendMessagesLimit {
+		end := i + batchSendMessagesLimit
+		if end > len(receivers) {
+			end = len(receivers)
+		}
+
+		req := &sendMessagesReq{}
+		req.FromUserId = message.GetSender()
+		req.ToUserIds = receivers[i:end]
+		req.OfflinePushInfo = message.GetOfflinePushInfo()
+		req.CloudCustomData = conv.String(message.GetCustomData())
+		req.MsgSeq = message.GetSerialNo()
+		req.MsgBody = message.GetBody()
+		req.MsgRandom = message.GetRandom()
+		req.SendMsgControl = message.GetSendMsgControl()
+		req.SyncOtherMachine = message.GetSyncOtherMachine()
+
+		resp := &sendMessagesResp{}
+
+		if err = a.client.Post(service, commandSendMessages, req, resp); err != nil {
+			return nil, err
+		}
+
+		if ret.MsgKey == "" {
+			ret.MsgKey = resp.MsgKey
+		}
+
+		failed := make(map[string]bool, len(resp.Errors))
+		for _, item := range resp.Errors {
+			failed[item.UserId] = true
+		}
+
+		for _, userId := range req.ToUserIds {
+			if !failed[userId] {
+				ret.MsgKeys[userId] = resp.MsgKey
+			}
+		}
 
-	ret = &SendMessagesRet{
-		MsgKey: resp.MsgKey,
-		Errors: resp.Errors,
+		ret.Errors = append(ret.Errors, resp.Errors...)
 	}
 
 	return
@@ -203,6 +233,7 @@ func (a *api) SendMessages(message *Message) (ret *SendMessagesRet, err error) {
 // 该接口不会触发回调。
 // 该接口会根据 From_Account ， To_Account ，MsgSeq ， MsgRandom ， MsgTimeStamp 字段的值对导入的消息进行去重。仅当这五个字段的值都对应相同时，才判定消息是重复的，消息是否重复与消息内容本身无关。
 // 重复导入的消息不会覆盖之前已导入的消息（即消息内容以首次导入的为准）。
+// 导入消息的时间戳不能晚于当前时间。
 // 单聊消息 MsgSeq 字段的作用及说明：该字段在发送消息时由用户自行指定，该值可以重复，非后台生成，非全局唯一。与群聊消息的 MsgSeq 字段不同，群聊消息的 MsgSeq 由后台生成，每个群都维护一个 MsgSeq，从1开始严格递增。单聊消息历史记录对同一个会话的消息先以时间戳排序，同秒内的消息再以 MsgSeq 排序。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/2568
@@ -211,6 +242,10 @@ func (a *api) ImportMessage(message *Message) (err error) {
 		return
 	}
 
+	if err = message.checkTimestampArgError(); err != nil {
+		return
+	}
+
 	req := &importMessageReq{}
 	req.FromUserId = message.GetSender()
 	req.ToUserId = message.GetLastReceiver()
@@ -240,6 +275,21 @@ func (a *api) ImportMessage(message *Message) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/42794
 func (a *api) FetchMessages(arg *FetchMessagesArg) (ret *FetchMessagesRet, err error) {
+	if arg.FromUserId == "" || arg.ToUserId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	if arg.MaxLimited <= 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the max limited must be greater than 0")
+		return
+	}
+
+	if arg.MinTime > arg.MaxTime {
+		err = core.NewError(enum.InvalidParamsCode, "the min time cannot be greater than the max time")
+		return
+	}
+
 	resp := &fetchMessagesResp{}
 
 	if err = a.client.Post(service, commandFetchMessages, arg, resp); err != nil {
@@ -305,6 +355,7 @@ func (a *api) PullMessages(arg *PullMessagesArg, fn func(ret *FetchMessagesRet))
 // 若需要撤回由 REST API 单发 和 批量发 接口发出的单聊消息，需要记录这些接口回包里的 MsgKey 字段以进行撤回。
 // 调用该接口撤回消息后，该条消息的离线、漫游存储，以及消息发送方和接收方的客户端的本地缓存都会被撤回。
 // 该接口可撤回的单聊消息没有时间限制，即可以撤回任何时间的单聊消息。
+// 待撤回的消息不存在时返回的错误满足 errors.Is(err, ErrMessageNotFound)。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/38980
 func (a *api) RevokeMessage(fromUserId, toUserId, msgKey string) (err error) {
@@ -318,12 +369,21 @@ func (a *api) RevokeMessage(fromUserId, toUserId, msgKey string) (err error) {
 }
 
 // SetMessageRead 设置单聊消息已读
-// 设置用户的某个单聊会话的消息全部已读。
+// 设置用户的某个单聊会话的消息已读，未指定已读时间或已读时间为0时设置全部消息已读。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/50349
-func (a *api) SetMessageRead(userId, peerUserId string) (err error) {
+func (a *api) SetMessageRead(userId, peerUserId string, msgReadTime ...int64) (err error) {
+	if userId == "" || peerUserId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	req := &setMessageReadReq{UserId: userId, PeerUserId: peerUserId}
 
+	if len(msgReadTime) > 0 {
+		req.MsgReadTime = msgReadTime[0]
+	}
+
 	if err = a.client.Post(service, commandSetMessageRead, req, &types.ActionBaseResp{}); err != nil {
 		return
 	}
@@ -333,9 +393,15 @@ func (a *api) SetMessageRead(userId, peerUserId string) (err error) {
 
 // GetUnreadMessageNum 查询单聊未读消息计数
 // App 后台可以通过该接口查询特定账号的单聊总未读数（包含所有的单聊会话）或者单个单聊会话的未读数。
+// 未指定会话对端时仅返回总未读数，指定时在结果中按对端UserId返回各会话的未读数。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/56043
 func (a *api) GetUnreadMessageNum(userId string, peerUserIds ...string) (ret *GetUnreadMessageNumRet, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	req := &getUnreadMessageNumReq{UserId: userId, PeerUserIds: peerUserIds}
 	resp := &getUnreadMessageNumResp{}
 
@@ -8,6 +8,7 @@
 package private
 
 import (
+    "github.com/dobyte/tencent-im/internal/core"
     "github.com/dobyte/tencent-im/internal/enum"
 )
 
@@ -18,7 +19,7 @@ const (
     
     // 推送标识
     PushFlagYes = enum.PushFlagYes // 正常推送
-    PushFlagNo  = enum.PushFlagYes // 不离线推送
+    PushFlagNo  = enum.PushFlagNo  // 不离线推送
     
     // 华为推送通知消息分类
     HuaWeiImportanceLow    = enum.HuaWeiImportanceLow    // LOW类消息
@@ -40,3 +41,6 @@ const (
     MutableContentNormal = enum.MutableContentNormal // 关闭iOS10的推送扩展
     MutableContentEnable = enum.MutableContentEnable // 开启iOS10的推送扩展
 )
+
+// ErrMessageNotFound 待撤回的消息不存在
+var ErrMessageNotFound = core.NewError(20022, "the message to be revoked does not exist")
@@ -9,12 +9,16 @@ package private
 
 import (
 	"errors"
+	"time"
 
 	"github.com/dobyte/tencent-im/internal/entity"
 	"github.com/dobyte/tencent-im/internal/types"
 )
 
-var errNotSetMsgReceiver = errors.New("message receiver is not set")
+var (
+	errNotSetMsgReceiver   = errors.New("message receiver is not set")
+	errInvalidMsgTimestamp = errors.New("message timestamp cannot be in the future")
+)
 
 type Message struct {
 	entity.Message
@@ -87,6 +91,7 @@ func (m *Message) GetTimestamp() int64 {
 }
 
 // SetCustomData 设置自定义数据
+// 即消息的 CloudCustomData（云端保存，会发送到对端，拉取历史消息及回调时返回），非字符串数据将被转换为字符串
 func (m *Message) SetCustomData(data interface{}) {
 	m.customData = data
 }
@@ -166,6 +171,10 @@ func (m *Message) CheckError() (err error) {
 		return
 	}
 
+	if err = m.CheckOfflinePushArgError(); err != nil {
+		return
+	}
+
 	if err = m.checkReceiverArgError(); err != nil {
 		return
 	}
@@ -181,3 +190,12 @@ func (m *Message) checkReceiverArgError() error {
 
 	return nil
 }
+
+// checkTimestampArgError 检测消息时间戳参数
+func (m *Message) checkTimestampArgError() error {
+	if m.timestamp > time.Now().Unix() {
+		return errInvalidMsgTimestamp
+	}
+
+	return nil
+}
@@ -26,7 +26,7 @@ type (
 		MsgTimeStamp          int64                  `json:"MsgTimeStamp,omitempty"`          // （选填）消息时间戳，UNIX 时间戳（单位：秒）
 		MsgBody               []*types.MsgBody       `json:"MsgBody"`                         // （必填）消息内容，具体格式请参考 消息格式描述（注意，一条消息可包括多种消息元素，MsgBody 为 Array 类型）
 		SyncOtherMachine      int                    `json:"SyncOtherMachine,omitempty"`      // （选填）消息是否同步到在线终端和漫游上 1：把消息同步到 From_Account 在线终端和漫游上；2：消息不同步至 From_Account； 若不填写默认情况下会将消息存 From_Account 漫游
-		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`       // （选填）消息回调禁止开关，只对本条消息有效，
+		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`       // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`        // （选填）消息发送控制选项，是一个 String 数组，只对本条消息有效。
 		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"` // （选填）消息回调禁止开关，只对本条消息有效
 		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`       // （选填）离线推送信息配置
@@ -41,8 +41,9 @@ type (
 
 	// SendMessageRet 发送消息结果
 	SendMessageRet struct {
-		MsgKey  string // 消息唯一标识，用于撤回。长度不超过50个字符
-		MsgTime int    // 消息时间戳，UNIX 时间戳
+		MsgKey    string // 消息唯一标识，用于撤回。长度不超过50个字符
+		MsgTime   int    // 消息时间戳，UNIX 时间戳
+		MsgRandom uint32 // 消息随机数，后台用于同一秒内的消息去重
 	}
 
 	// 批量发单聊消息（请求）
@@ -53,7 +54,7 @@ type (
 		MsgRandom        uint32                 `json:"MsgRandom"`                  // （必填）消息随机数，后台用于同一秒内的消息去重。请确保该字段填的是随机数
 		MsgBody          []*types.MsgBody       `json:"MsgBody"`                    // （必填）消息内容，具体格式请参考 消息格式描述（注意，一条消息可包括多种消息元素，MsgBody 为 Array 类型）
 		SyncOtherMachine int                    `json:"SyncOtherMachine,omitempty"` // （选填）消息是否同步到在线终端和漫游上 1：把消息同步到 From_Account 在线终端和漫游上；2：消息不同步至 From_Account； 若不填写默认情况下会将消息存 From_Account 漫游
-		CloudCustomData  string                 `json:"CloudCustomData,omitempty"`  // （选填）消息回调禁止开关，只对本条消息有效，
+		CloudCustomData  string                 `json:"CloudCustomData,omitempty"`  // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 		SendMsgControl   []string               `json:"SendMsgControl,omitempty"`   // （选填）消息发送控制选项，是一个 String 数组，只对本条消息有效。
 		OfflinePushInfo  *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`  // （选填）离线推送信息配置
 	}
@@ -73,8 +74,9 @@ type (
 
 	// SendMessagesRet 发送消息结果
 	SendMessagesRet struct {
-		MsgKey string
-		Errors []SendMessageError
+		MsgKey  string             // 消息唯一标识，分批发送时为第一批消息的标识
+		MsgKeys map[string]string  // 发送成功的接收方UserId => 消息唯一标识
+		Errors  []SendMessageError // 发送失败的接收方列表
 	}
 
 	// 导入消息（请求）
@@ -86,7 +88,7 @@ type (
 		MsgTimeStamp      int64            `json:"MsgTimeStamp,omitempty"`      // （选填）消息时间戳，UNIX 时间戳（单位：秒）
 		MsgBody           []*types.MsgBody `json:"MsgBody"`                     // （必填）消息内容，具体格式请参考 消息格式描述（注意，一条消息可包括多种消息元素，MsgBody 为 Array 类型）
 		SyncFromOldSystem int              `json:"SyncFromOldSystem,omitempty"` // （选填）消息是否同步到在线终端和漫游上 1：把消息同步到 From_Account 在线终端和漫游上；2：消息不同步至 From_Account； 若不填写默认情况下会将消息存 From_Account 漫游
-		CloudCustomData   string           `json:"CloudCustomData,omitempty"`   // （选填）消息回调禁止开关，只对本条消息有效，
+		CloudCustomData   string           `json:"CloudCustomData,omitempty"`   // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 	}
 
 	// FetchMessagesArg 拉取消息参数
@@ -128,7 +130,7 @@ type (
 		MsgFlagBits     int              `json:"MsgFlagBits"`
 		MsgKey          string           `json:"MsgKey"`
 		MsgBody         []*types.MsgBody `json:"MsgBody"`
-		CloudCustomData string           `json:"CloudCustomData"`
+		CloudCustomData string           `json:"CloudCustomData"` // 消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 	}
 
 	// PullMessagesArg 持续拉取单聊消息参数
@@ -149,8 +151,9 @@ type (
 
 	// 设置单聊消息已读（请求）
 	setMessageReadReq struct {
-		UserId     string `json:"Report_Account"` // （必填）进行消息已读的用户UserId
-		PeerUserId string `json:"Peer_Account"`   // （必填）进行消息已读的单聊会话的另一方用户UserId
+		UserId      string `json:"Report_Account"`        // （必填）进行消息已读的用户UserId
+		PeerUserId  string `json:"Peer_Account"`          // （必填）进行消息已读的单聊会话的另一方用户UserId
+		MsgReadTime int64  `json:"MsgReadTime,omitempty"` // （选填）时间戳（秒），该时间戳之前的消息全部已读。若不填，则取当前时间戳
 	}
 
 	// 查询单聊未读消息计数（请求）
@@ -202,6 +205,7 @@ type (
 		MsgVersion      int              `json:"MsgVersion"`
 	}
 
+	MsgBody            = types.MsgBody
 	ImageInfo          = types.ImageInfo
 	MsgTextContent     = types.MsgTextContent
 	MsgFaceContent     = types.MsgFaceContent
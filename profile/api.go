@@ -8,6 +8,7 @@
 package profile
 
 import (
+	"github.com/dobyte/tencent-im/internal/batch"
 	"github.com/dobyte/tencent-im/internal/core"
 	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/types"
@@ -17,19 +18,31 @@ const (
 	service            = "profile"
 	commandSetProfile  = "portrait_set"
 	commandGetProfiles = "portrait_get"
+
+	batchGetProfilesLimit = 100 // 批量获取资料限制
 )
 
 type API interface {
 	// SetProfile 设置资料
 	// 支持 标配资料字段 和 自定义资料字段 的设置
+	// 自定义资料字段需以 Tag_Profile_Custom_ 为前缀且名称不超过8个字符，否则返回的错误满足 errors.Is(err, ErrInvalidProfileTag)。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1640
 	SetProfile(profile *Profile) (err error)
 
+	// SetProfiles 批量设置资料
+	// 本方法拓展于“设置资料（SetProfile）”方法。
+	// 后台接口仅支持设置单个用户的资料，本方法以 concurrency 指定的并发数（不大于0时默认为5）逐个设置，请求频率受客户端限流控制，上下文取消后未设置的用户将返回上下文的错误。
+	// 返回的结果与 profiles 一一对应，单个用户设置失败时可通过 SetProfileResult.Err 获取其错误信息。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1640
+	SetProfiles(profiles []*Profile, concurrency int) (results []*SetProfileResult, err error)
+
 	// GetProfiles 拉取资料
 	// 支持拉取好友和非好友的资料字段。
 	// 支持拉取 标配资料字段 和 自定义资料字段。
 	// 建议每次拉取的用户数不超过100，避免因回包数据量太大导致回包失败。
+	// 单次拉取超过100个用户时会自动分批请求，单个用户拉取失败时可通过 Profile.IsValid 和 Profile.GetError 获取其错误信息。
 	// 请确保请求中的所有帐号都已导入即时通信 IM，如果请求中含有未导入即时通信 IM 的帐号，即时通信 IM 后台将会提示错误。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1639
@@ -46,6 +59,7 @@ func NewAPI(client core.Client) API {
 
 // SetProfile 设置资料
 // 支持 标配资料字段 和 自定义资料字段 的设置
+// 自定义资料字段需以 Tag_Profile_Custom_ 为前缀且名称不超过8个字符，否则返回的错误满足 errors.Is(err, ErrInvalidProfileTag)。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1640
 func (a *api) SetProfile(profile *Profile) (err error) {
@@ -78,28 +92,84 @@ func (a *api) SetProfile(profile *Profile) (err error) {
 	return
 }
 
+// SetProfiles 批量设置资料
+// 本方法拓展于“设置资料（SetProfile）”方法。
+// 后台接口仅支持设置单个用户的资料，本方法以 concurrency 指定的并发数（不大于0时默认为5）逐个设置，请求频率受客户端限流控制，上下文取消后未设置的用户将返回上下文的错误。
+// 返回的结果与 profiles 一一对应，单个用户设置失败时可通过 SetProfileResult.Err 获取其错误信息。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1640
+func (a *api) SetProfiles(profiles []*Profile, concurrency int) (results []*SetProfileResult, err error) {
+	if len(profiles) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the profiles is not set")
+		return
+	}
+
+	results = make([]*SetProfileResult, len(profiles))
+	for i, profile := range profiles {
+		results[i] = &SetProfileResult{}
+		if profile != nil {
+			results[i].UserId = profile.GetUserId()
+		}
+	}
+
+	e := batch.Execute(a.client.Context(), len(profiles), concurrency, func(i int) error {
+		if profiles[i] == nil {
+			return core.NewError(enum.InvalidParamsCode, "the profile is not set")
+		}
+
+		return a.SetProfile(profiles[i])
+	})
+
+	if errs, ok := e.(batch.Errors); ok {
+		for i := range errs {
+			results[i].Err = errs[i]
+		}
+	}
+
+	return
+}
+
 // GetProfiles 拉取资料
 // 支持拉取好友和非好友的资料字段。
 // 支持拉取 标配资料字段 和 自定义资料字段。
 // 建议每次拉取的用户数不超过100，避免因回包数据量太大导致回包失败。
+// 单次拉取超过100个用户时会自动分批请求，单个用户拉取失败时可通过 Profile.IsValid 和 Profile.GetError 获取其错误信息。
 // 请确保请求中的所有帐号都已导入即时通信 IM，如果请求中含有未导入即时通信 IM 的帐号，即时通信 IM 后台将会提示错误。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1639
 func (a *api) GetProfiles(userIds []string, attrs []string) (profiles []*Profile, err error) {
-	req := &getProfileReq{UserIds: userIds, TagList: attrs}
-	resp := &getProfileResp{}
+	if len(userIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the accounts is not set")
+		return
+	}
 
-	if err = a.client.Post(service, commandGetProfiles, req, resp); err != nil {
+	if len(attrs) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the attributes is not set")
 		return
 	}
 
-	for _, account := range resp.UserProfiles {
-		p := NewProfile(account.UserId)
-		p.SetError(account.ResultCode, account.ResultInfo)
-		for _, item := range account.Profile {
-			p.SetAttr(item.Tag, item.Value)
+	profiles = make([]*Profile, 0, len(userIds))
+	for i := 0; i < len(userIds); i += batchGetProfilesLimit {
+		end := i + batchGetProfilesLimit
+		if end > len(userIds) {
+			end = len(userIds)
+		}
+
+		req := &getProfileReq{UserIds: userIds[i:end], TagList: attrs}
+		resp := &getProfileResp{}
+
+		if err = a.client.Post(service, commandGetProfiles, req, resp); err != nil {
+			return
+		}
+
+		for _, account := range resp.UserProfiles {
+			p := NewProfile(account.UserId)
+			p.SetError(account.ResultCode, account.ResultInfo)
+			for _, item := range account.Profile {
+				p.SetAttr(item.Tag, item.Value)
+			}
+			profiles = append(profiles, p)
 		}
-		profiles = append(profiles, p)
 	}
 
 	return
@@ -8,6 +8,8 @@
 package profile
 
 import (
+	"errors"
+
 	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/types"
 )
@@ -52,3 +54,6 @@ const (
 	StandardAttrLevel           = enum.StandardAttrLevel           // 等级
 	StandardAttrRole            = enum.StandardAttrRole            // 角色
 )
+
+// ErrInvalidProfileTag 无效的资料字段，自定义资料字段需以 Tag_Profile_Custom_ 为前缀且名称不超过8个字符
+var ErrInvalidProfileTag = errors.New("invalid profile tag")
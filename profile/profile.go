@@ -8,11 +8,19 @@
 package profile
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dobyte/tencent-im/internal/core"
 	"github.com/dobyte/tencent-im/internal/entity"
 	"github.com/dobyte/tencent-im/internal/enum"
 )
 
+const (
+	customAttrPrefix     = enum.CustomAttrPrefix + "_" // 自定义资料字段前缀
+	maxCustomAttrNameLen = 8                           // 自定义资料字段名称的最大长度
+)
+
 type Profile struct {
 	entity.User
 }
@@ -35,5 +43,25 @@ func (p *Profile) CheckError() (err error) {
 		return
 	}
 
+	for tag := range p.GetAttrs() {
+		if !isValidTag(tag) {
+			return fmt.Errorf("%w: %s", ErrInvalidProfileTag, tag)
+		}
+	}
+
 	return
 }
+
+// 检测资料字段是否有效
+func isValidTag(tag string) bool {
+	switch tag {
+	case StandardAttrNickname, StandardAttrGender, StandardAttrBirthday, StandardAttrLocation,
+		StandardAttrSignature, StandardAttrAllowType, StandardAttrLanguage, StandardAttrAvatar,
+		StandardAttrMsgSettings, StandardAttrAdminForbidType, StandardAttrLevel, StandardAttrRole:
+		return true
+	}
+
+	name := strings.TrimPrefix(tag, customAttrPrefix)
+
+	return name != tag && name != "" && len(name) <= maxCustomAttrNameLen
+}
@@ -16,6 +16,12 @@ type (
 		Attrs  []*types.TagPair `json:"ProfileItem"`  // （必填）待设置的用户的资料对象数组
 	}
 
+	// SetProfileResult 批量设置资料结果
+	SetProfileResult struct {
+		UserId string // 用户ID
+		Err    error  // 设置失败时的错误信息，成功时为nil
+	}
+
 	// 获取资料（请求）
 	getProfileReq struct {
 		UserIds []string `json:"To_Account"` // （必填）需要拉取这些UserID的资料
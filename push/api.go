@@ -19,6 +19,7 @@ import (
 const (
 	service                  = "all_member_push"
 	commandPushMessage       = "im_push"
+	commandStopPush          = "im_stop_push"
 	commandSetAttrNames      = "im_set_attr_name"
 	commandGetAttrNames      = "im_get_attr_name"
 	commandGetUserAttrs      = "im_get_attr"
@@ -37,6 +38,10 @@ const (
 	batchGetUserTagsLimit           = 100 // 批量获取用户标签限制
 	batchDeleteUserTagsLimit        = 100 // 批量删除用户标签限制
 	batchDeleteUserAllTagsUserLimit = 100 // 批量删除用户所有标签的用户限制
+
+	maxAttrNameIndex   = 9  // 应用属性名称的最大序号
+	batchAddUserTagNum = 10 // 单个用户单次添加标签数限制
+	maxUserTagLen      = 50 // 单个标签最大长度
 )
 
 type API interface {
@@ -52,6 +57,13 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/45934
 	PushMessage(message *Message) (taskId string, err error)
 
+	// StopPush 停止全员推送
+	// 停止尚未下发完成的全员推送任务，taskId 为“全员推送（PushMessage）”返回的推送任务ID。
+	// 推送任务已下发完成时返回的错误满足 errors.Is(err, ErrPushTaskFinished)，其他错误仍需按停止失败处理。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/45934
+	StopPush(taskId string) (err error)
+
 	// SetAttrNames 设置应用属性名称
 	// 每个应用可以设置自定义的用户属性，最多可以有10个。通过本接口可以设置每个属性的名称，设置完成后，即可用于按用户属性推送等。
 	// 点击查看详细文档:
@@ -152,6 +164,26 @@ func (a *api) PushMessage(message *Message) (taskId string, err error) {
 	return
 }
 
+// StopPush 停止全员推送
+// 停止尚未下发完成的全员推送任务，taskId 为“全员推送（PushMessage）”返回的推送任务ID。
+// 推送任务已下发完成时返回的错误满足 errors.Is(err, ErrPushTaskFinished)，其他错误仍需按停止失败处理。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/45934
+func (a *api) StopPush(taskId string) (err error) {
+	if taskId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the task id is not set")
+		return
+	}
+
+	req := &stopPushReq{TaskId: taskId}
+
+	if err = a.client.Post(service, commandStopPush, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
 // SetAttrNames 设置应用属性名称
 // 每个应用可以设置自定义的用户属性，最多可以有10个。通过本接口可以设置每个属性的名称，设置完成后，即可用于按用户属性推送等。
 // 点击查看详细文档:
@@ -168,6 +200,16 @@ func (a *api) SetAttrNames(attrNames map[int]string) (err error) {
 	req := &setAttrNamesReq{AttrNames: make(map[string]string, len(attrNames))}
 
 	for i, attrName := range attrNames {
+		if i < 0 || i > maxAttrNameIndex {
+			err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the index of attribute name must be between 0 and %d", maxAttrNameIndex))
+			return
+		}
+
+		if attrName == "" {
+			err = core.NewError(enum.InvalidParamsCode, "the attribute name is not set")
+			return
+		}
+
 		req.AttrNames[strconv.Itoa(i)] = attrName
 	}
 
@@ -245,6 +287,11 @@ func (a *api) SetUserAttrs(userAttrs map[string]map[string]interface{}) (err err
 
 	req := &setUserAttrsReq{Attrs: make([]*userAttrItem, 0, len(userAttrs))}
 	for userId, attrs := range userAttrs {
+		if userId == "" {
+			err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+			return
+		}
+
 		req.Attrs = append(req.Attrs, &userAttrItem{
 			UserId: userId,
 			Attrs:  attrs,
@@ -273,6 +320,11 @@ func (a *api) DeleteUserAttrs(userAttrs map[string][]string) (err error) {
 
 	req := &deleteUserAttrsReq{Attrs: make([]deleteUserAttr, 0, len(userAttrs))}
 	for userId, attrs := range userAttrs {
+		if userId == "" {
+			err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+			return
+		}
+
 		req.Attrs = append(req.Attrs, deleteUserAttr{
 			UserId: userId,
 			Attrs:  attrs,
@@ -334,6 +386,23 @@ func (a *api) AddUserTags(userTags map[string][]string) (err error) {
 
 	req := &addUserTagsReq{Tags: make([]*userTag, 0, len(userTags))}
 	for userId, tags := range userTags {
+		if userId == "" {
+			err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+			return
+		}
+
+		if len(tags) > batchAddUserTagNum {
+			err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of tags added to a user cannot exceed %d", batchAddUserTagNum))
+			return
+		}
+
+		for _, tag := range tags {
+			if tag == "" || len(tag) > maxUserTagLen {
+				err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the length of tag must be between 1 and %d bytes", maxUserTagLen))
+				return
+			}
+		}
+
 		req.Tags = append(req.Tags, &userTag{
 			UserId: userId,
 			Tags:   tags,
@@ -362,6 +431,11 @@ func (a *api) DeleteUserTags(userTags map[string][]string) (err error) {
 
 	req := &deleteUserTagsReq{Tags: make([]*userTag, 0, len(userTags))}
 	for userId, tags := range userTags {
+		if userId == "" {
+			err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+			return
+		}
+
 		req.Tags = append(req.Tags, &userTag{
 			UserId: userId,
 			Tags:   tags,
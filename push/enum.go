@@ -8,13 +8,14 @@
 package push
 
 import (
+    "github.com/dobyte/tencent-im/internal/core"
     "github.com/dobyte/tencent-im/internal/enum"
 )
 
 const (
     // 推送标识
     PushFlagYes = enum.PushFlagYes // 正常推送
-    PushFlagNo  = enum.PushFlagYes // 不离线推送
+    PushFlagNo  = enum.PushFlagNo  // 不离线推送
     
     // 华为推送通知消息分类
     HuaWeiImportanceLow    = enum.HuaWeiImportanceLow    // LOW类消息
@@ -36,3 +37,6 @@ const (
     MutableContentNormal = enum.MutableContentNormal // 关闭iOS10的推送扩展
     MutableContentEnable = enum.MutableContentEnable // 开启iOS10的推送扩展
 )
+
+// ErrPushTaskFinished 待停止的全员推送任务已下发完成或不存在
+var ErrPushTaskFinished = core.NewError(90063, "the push task has already finished")
@@ -9,11 +9,21 @@ package push
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/dobyte/tencent-im/internal/entity"
 )
 
-var errInvalidPushCondition = errors.New("attrs and tags condition cannot be set at the same time")
+const (
+	maxConditionTagsNum = 10 // 推送条件中的最大标签数
+	maxConditionTagLen  = 50 // 推送条件中的单个标签最大长度
+)
+
+var (
+	errInvalidPushCondition = errors.New("attrs and tags condition cannot be set at the same time")
+	errTooManyConditionTags = fmt.Errorf("the number of condition tags cannot exceed %d", maxConditionTagsNum)
+	errTooLongConditionTag  = fmt.Errorf("the length of condition tag cannot exceed %d bytes", maxConditionTagLen)
+)
 
 type Message struct {
 	entity.Message
@@ -123,6 +133,10 @@ func (m *Message) checkError() (err error) {
 		return
 	}
 
+	if err = m.CheckOfflinePushArgError(); err != nil {
+		return
+	}
+
 	if err = m.checkConditionArgError(); err != nil {
 		return
 	}
@@ -135,11 +149,11 @@ func (m *Message) checkConditionArgError() error {
 	hasAttrs, hasTags := false, false
 
 	if m.condition != nil {
-		if m.condition.AttrsAnd != nil || m.condition.AttrsOr != nil {
+		if len(m.condition.AttrsAnd) > 0 || len(m.condition.AttrsOr) > 0 {
 			hasAttrs = true
 		}
 
-		if m.condition.TagsAnd != nil || m.condition.TagsOr != nil {
+		if len(m.condition.TagsAnd) > 0 || len(m.condition.TagsOr) > 0 {
 			hasTags = true
 		}
 	}
@@ -148,5 +162,19 @@ func (m *Message) checkConditionArgError() error {
 		return errInvalidPushCondition
 	}
 
+	if hasTags {
+		for _, tags := range [][]string{m.condition.TagsAnd, m.condition.TagsOr} {
+			if len(tags) > maxConditionTagsNum {
+				return errTooManyConditionTags
+			}
+
+			for _, tag := range tags {
+				if len(tag) > maxConditionTagLen {
+					return errTooLongConditionTag
+				}
+			}
+		}
+	}
+
 	return nil
 }
@@ -12,10 +12,10 @@ import "github.com/dobyte/tencent-im/internal/types"
 type (
 	// 推送条件
 	condition struct {
-		TagsAnd  []string               `json:"TagsAnd"`  // （选填）标签条件的交集。标签是一个不超过50字节的字符串。注意属性推送和标签推送不可同时作为推送条件。TagsAnd 条件中的标签个数不能超过10个
-		TagsOr   []string               `json:"TagsOr"`   // （选填）标签条件的并集。标签是一个不超过50字节的字符串。注意属性推送和标签推送不可同时作为推送条件。TagsOr 条件中的标签个数不能超过10个
-		AttrsAnd map[string]interface{} `json:"AttrsAnd"` // （选填）属性条件的交集。注意属性推送和标签推送不可同时作为推送条件
-		AttrsOr  map[string]interface{} `json:"AttrsOr"`  // （选填）属性条件的并集。注意属性推送和标签推送不可同时作为推送条件
+		TagsAnd  []string               `json:"TagsAnd,omitempty"`  // （选填）标签条件的交集。标签是一个不超过50字节的字符串。注意属性推送和标签推送不可同时作为推送条件。TagsAnd 条件中的标签个数不能超过10个
+		TagsOr   []string               `json:"TagsOr,omitempty"`   // （选填）标签条件的并集。标签是一个不超过50字节的字符串。注意属性推送和标签推送不可同时作为推送条件。TagsOr 条件中的标签个数不能超过10个
+		AttrsAnd map[string]interface{} `json:"AttrsAnd,omitempty"` // （选填）属性条件的交集。注意属性推送和标签推送不可同时作为推送条件
+		AttrsOr  map[string]interface{} `json:"AttrsOr,omitempty"`  // （选填）属性条件的并集。注意属性推送和标签推送不可同时作为推送条件
 	}
 
 	// 推送（请求）
@@ -34,6 +34,11 @@ type (
 		TaskId string `json:"TaskId"` // 推送任务ID
 	}
 
+	// 停止推送（请求）
+	stopPushReq struct {
+		TaskId string `json:"TaskId"` // （必填）推送任务ID
+	}
+
 	// 设置应用属性名称（请求）
 	setAttrNamesReq struct {
 		AttrNames map[string]string `json:"AttrNames"` // （必填）属性名
@@ -114,6 +119,7 @@ type (
 		UserIds []string `json:"To_Account"`
 	}
 
+	MsgBody            = types.MsgBody
 	ImageInfo          = types.ImageInfo
 	MsgTextContent     = types.MsgTextContent
 	MsgFaceContent     = types.MsgFaceContent
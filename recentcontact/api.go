@@ -9,6 +9,7 @@ package recentcontact
 
 import (
 	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
 	"github.com/dobyte/tencent-im/internal/types"
 )
 
@@ -18,6 +19,11 @@ const (
 	commandDeleteSession = "delete"
 )
 
+var (
+	errNotSetUserId   = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+	errInvalidPageArg = core.NewError(enum.InvalidParamsCode, "the paging arguments cannot be negative")
+)
+
 type API interface {
 	// FetchSessions 拉取会话列表
 	// 支持分页拉取会话列表
@@ -34,9 +40,10 @@ type API interface {
 
 	// DeleteSession 删除单个会话
 	// 删除指定会话，支持同步清理漫游消息。
+	// C2C 会话时 toUserId 为会话方的 UserID，G2C 会话时 toUserId 为群 ID。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/62119
-	DeleteSession(fromUserId, toUserId string, SessionType SessionType, isClearRamble ...bool) (err error)
+	DeleteSession(fromUserId, toUserId string, sessionType SessionType, isClearRamble ...bool) (err error)
 }
 
 type api struct {
@@ -52,6 +59,16 @@ func NewAPI(client core.Client) API {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/62118
 func (a *api) FetchSessions(arg *FetchSessionsArg) (ret *FetchSessionsRet, err error) {
+	if arg == nil || arg.UserId == "" {
+		err = errNotSetUserId
+		return
+	}
+
+	if arg.TimeStamp < 0 || arg.StartIndex < 0 || arg.TopTimeStamp < 0 || arg.TopStartIndex < 0 {
+		err = errInvalidPageArg
+		return
+	}
+
 	req := &fetchSessionsReq{
 		UserId:        arg.UserId,
 		TimeStamp:     arg.TimeStamp,
@@ -96,6 +113,11 @@ func (a *api) FetchSessions(arg *FetchSessionsArg) (ret *FetchSessionsRet, err e
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/62118
 func (a *api) PullSessions(arg *PullSessionsArg, fn func(ret *FetchSessionsRet)) (err error) {
+	if arg == nil || arg.UserId == "" {
+		err = errNotSetUserId
+		return
+	}
+
 	var (
 		ret *FetchSessionsRet
 		req = &FetchSessionsArg{
@@ -127,13 +149,30 @@ func (a *api) PullSessions(arg *PullSessionsArg, fn func(ret *FetchSessionsRet))
 
 // DeleteSession 删除单个会话
 // 删除指定会话，支持同步清理漫游消息。
+// C2C 会话时 toUserId 为会话方的 UserID，G2C 会话时 toUserId 为群 ID。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/62119
-func (a *api) DeleteSession(fromUserId, toUserId string, SessionType SessionType, isClearRamble ...bool) (err error) {
-	req := &deleteSessionReq{
-		FromUserId: fromUserId,
-		ToUserId:   toUserId,
-		Type:       SessionType,
+func (a *api) DeleteSession(fromUserId, toUserId string, sessionType SessionType, isClearRamble ...bool) (err error) {
+	if fromUserId == "" {
+		err = errNotSetUserId
+		return
+	}
+
+	if toUserId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the session's account or group id is not set")
+		return
+	}
+
+	req := &deleteSessionReq{FromUserId: fromUserId, Type: sessionType}
+
+	switch sessionType {
+	case SessionTypeC2C:
+		req.ToUserId = toUserId
+	case SessionTypeG2C:
+		req.ToGroupId = toUserId
+	default:
+		err = core.NewError(enum.InvalidParamsCode, "invalid session type")
+		return
 	}
 
 	if len(isClearRamble) > 0 && isClearRamble[0] {
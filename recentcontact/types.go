@@ -73,6 +73,7 @@ type SessionItem struct {
 type deleteSessionReq struct {
 	FromUserId  string      `json:"From_Account"`          // （必填）请求删除该 UserID 的会话
 	Type        SessionType `json:"type"`                  // （必填）会话类型：1 表示 C2C 会话；2 表示 G2C 会话
-	ToUserId    string      `json:"To_Account"`            // （必填）待删除的会话的 UserID
+	ToUserId    string      `json:"To_Account,omitempty"`  // （选填）待删除的会话的 UserID，C2C 会话时必填
+	ToGroupId   string      `json:"ToGroupid,omitempty"`   // （选填）待删除的会话的群 ID，G2C 会话时必填
 	ClearRamble int         `json:"ClearRamble,omitempty"` // （选填）是否清理漫游消息：1 表示清理漫游消息；0 表示不清理漫游消息
 }
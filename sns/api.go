@@ -33,6 +33,8 @@ const (
 	commandDeleteGroup     = "group_delete"
 	commandGetGroup        = "group_get"
 
+	batchImportFriendsLimit   = 1000 // 批量导入好友限制
+	batchDeleteFriendsLimit   = 1000 // 批量删除好友限制
 	batchCheckFriendsLimit    = 100  // 批量校验好友限制
 	batchGetFriendsLimit      = 100  // 批量获取好友限制
 	batchAddBlacklistLimit    = 1000 // 批量添加黑名单限制
@@ -48,12 +50,14 @@ type API interface {
 	// AddFriend 添加单个好友
 	// 本方法拓展于“添加多个好友（AddFriends）”方法。
 	// 添加好友，仅支持添加单个好友
+	// 已经是好友或等待对方验证时，返回的错误分别满足 errors.Is(err, ErrAlreadyFriends) 和 errors.Is(err, ErrAddFriendPending)。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1643
 	AddFriend(userId string, isBothAdd, isForceAdd bool, friend *Friend) (err error)
 
 	// AddFriends 添加多个好友
 	// 添加好友，支持批量添加好友
+	// 可通过 errors.Is(result.Err(), ErrAlreadyFriends) 等方式区分单个好友的添加结果。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1643
 	AddFriends(userId string, isBothAdd, isForceAdd bool, friends ...*Friend) (results []*Result, err error)
@@ -66,6 +70,7 @@ type API interface {
 
 	// ImportFriends 导入多个好友
 	// 支持批量导入单向好友。
+	// 导入好友无需对方同意，单次导入超过1000个好友时会自动分批请求。
 	// 往同一个用户导入好友时建议采用批量导入的方式，避免并发写导致的写冲突。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/8301
@@ -79,6 +84,7 @@ type API interface {
 
 	// UpdateFriends 更新多个好友
 	// 支持批量更新同一用户的多个好友的关系链数据。
+	// 仅会更新好友已设置的备注、分组和自定义关系数据，未设置的字段保持不变。
 	// 更新一个用户多个好友时，建议采用批量方式，避免并发写导致的写冲突。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/12525
@@ -92,6 +98,8 @@ type API interface {
 
 	// DeleteFriends 删除多个好友
 	// 删除好友，支持单向删除好友和双向删除好友。
+	// isBothDelete 为 true 时双向删除（Delete_Type_Both），否则单向删除（Delete_Type_Single）。
+	// 待删除的好友超过1000个时将自动分批请求，并合并每个好友的处理结果。
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/1644
 	DeleteFriends(userId string, isBothDelete bool, deletedUserIds ...string) (results []*Result, err error)
@@ -114,6 +122,14 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1646
 	CheckFriends(userId string, checkType CheckType, checkedUserIds ...string) (results []*CheckResult, err error)
 
+	// CheckFriendRelations 批量获取好友关系
+	// 本方法拓展于“校验多个好友（CheckFriends）”方法。
+	// 返回以被校验用户 UserID 为键、好友关系（CheckResultTypeNoRelation、CheckResultTypeAWithB、CheckResultTypeBWithA、CheckResultTypeBothWay）为值的映射。
+	// 被校验用户超过100个时将自动分批请求，校验失败的用户不会出现在返回结果中。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1646
+	CheckFriendRelations(userId string, checkType CheckType, checkedUserIds ...string) (relations map[string]string, err error)
+
 	// GetFriend 拉取单个指定好友
 	// 本方法拓展于“拉取多个指定好友（GetFriends）”方法。
 	// 支持拉取指定好友的好友数据和资料数据。
@@ -145,6 +161,14 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1647
 	PullFriends(userId string, fn func(ret *FetchFriendsRet)) (err error)
 
+	// FetchFriendsWithProfiles 拉取好友及指定字段
+	// 本方法拓展于“拉取好友（FetchFriends）”和“拉取多个指定好友（GetFriends）”方法。
+	// 分页拉取好友数据，并为当前页的好友补充 tagList 指定的资料字段及好友字段。
+	// tagList 为空时等同于“拉取好友（FetchFriends）”方法。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1647
+	FetchFriendsWithProfiles(userId string, startIndex int, tagList []string) (ret *FetchFriendsRet, err error)
+
 	// AddBlacklist 添加黑名单
 	// 添加黑名单，支持批量添加黑名单。
 	// 点击查看详细文档:
@@ -205,6 +229,7 @@ func NewAPI(client core.Client) API {
 // AddFriend 添加单个好友
 // 本方法拓展于“添加多个好友（AddFriends）”方法。
 // 添加好友，仅支持添加单个好友
+// 已经是好友或等待对方验证时，返回的错误分别满足 errors.Is(err, ErrAlreadyFriends) 和 errors.Is(err, ErrAddFriendPending)。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1643
 func (a *api) AddFriend(userId string, isBothAdd, isForceAdd bool, friend *Friend) (err error) {
@@ -225,9 +250,15 @@ func (a *api) AddFriend(userId string, isBothAdd, isForceAdd bool, friend *Frien
 
 // AddFriends 添加多个好友
 // 添加好友，支持批量添加好友
+// 可通过 errors.Is(result.Err(), ErrAlreadyFriends) 等方式区分单个好友的添加结果。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1643
 func (a *api) AddFriends(userId string, isBothAdd, isForceAdd bool, friends ...*Friend) (results []*Result, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if len(friends) == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the friends is not set")
 		return
@@ -245,7 +276,7 @@ func (a *api) AddFriends(userId string, isBothAdd, isForceAdd bool, friends ...*
 		item.Remark, _ = friend.GetRemark()
 		item.AddWording, _ = friend.GetAddWording()
 		item.AddSource, _ = friend.GetSrcAddSource()
-		if groups, exist := friend.GetGroup(); exist {
+		if groups, _ := friend.GetGroup(); len(groups) > 0 {
 			item.GroupName = groups[0]
 		}
 
@@ -297,52 +328,68 @@ func (a *api) ImportFriend(userId string, friend *Friend) (err error) {
 
 // ImportFriends 导入多个好友
 // 支持批量导入单向好友。
+// 导入好友无需对方同意，单次导入超过1000个好友时会自动分批请求。
 // 往同一个用户导入好友时建议采用批量导入的方式，避免并发写导致的写冲突。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/8301
 func (a *api) ImportFriends(userId string, friends ...*Friend) (results []*Result, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if len(friends) == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the friends is not set")
 		return
 	}
 
-	req := &importFriendsReq{UserId: userId, Friends: make([]*importFriendItem, 0, len(friends))}
-
 	for _, friend := range friends {
 		if err = friend.checkError(); err != nil {
 			return
 		}
+	}
 
-		item := new(importFriendItem)
-		item.UserId = friend.GetUserId()
-		item.Remark, _ = friend.GetRemark()
-		item.AddWording, _ = friend.GetAddWording()
-		item.AddTime, _ = friend.GetAddTime()
-		item.RemarkTime, _ = friend.GetRemarkTime()
-		item.AddSource, _ = friend.GetSrcAddSource()
-		item.GroupName, _ = friend.GetGroup()
+	results = make([]*Result, 0, len(friends))
+	for i := 0; i < len(friends); i += batchImportFriendsLimit {
+		end := i + batchImportFriendsLimit
+		if end > len(friends) {
+			end = len(friends)
+		}
 
-		if customAttrs := friend.GetSNSCustomAttrs(); len(customAttrs) > 0 {
-			item.CustomData = make([]*types.TagPair, 0, len(customAttrs))
-			for k, v := range customAttrs {
-				item.CustomData = append(item.CustomData, &types.TagPair{
-					Tag:   k,
-					Value: v,
-				})
+		req := &importFriendsReq{UserId: userId, Friends: make([]*importFriendItem, 0, end-i)}
+
+		for _, friend := range friends[i:end] {
+			item := new(importFriendItem)
+			item.UserId = friend.GetUserId()
+			item.Remark, _ = friend.GetRemark()
+			item.AddWording, _ = friend.GetAddWording()
+			item.AddTime, _ = friend.GetAddTime()
+			item.RemarkTime, _ = friend.GetRemarkTime()
+			item.AddSource, _ = friend.GetSrcAddSource()
+			item.GroupName, _ = friend.GetGroup()
+
+			if customAttrs := friend.GetSNSCustomAttrs(); len(customAttrs) > 0 {
+				item.CustomData = make([]*types.TagPair, 0, len(customAttrs))
+				for k, v := range customAttrs {
+					item.CustomData = append(item.CustomData, &types.TagPair{
+						Tag:   k,
+						Value: v,
+					})
+				}
 			}
+
+			req.Friends = append(req.Friends, item)
 		}
 
-		req.Friends = append(req.Friends, item)
-	}
+		resp := &importFriendsResp{}
 
-	resp := &importFriendsResp{}
+		if err = a.client.Post(service, commandImportFriend, req, resp); err != nil {
+			return
+		}
 
-	if err = a.client.Post(service, commandImportFriend, req, resp); err != nil {
-		return
+		results = append(results, resp.Results...)
 	}
 
-	results = resp.Results
-
 	return
 }
 
@@ -368,10 +415,16 @@ func (a *api) UpdateFriend(userId string, friend *Friend) (err error) {
 
 // UpdateFriends 更新多个好友
 // 支持批量更新同一用户的多个好友的关系链数据。
+// 仅会更新好友已设置的备注、分组和自定义关系数据，未设置的字段保持不变。
 // 更新一个用户多个好友时，建议采用批量方式，避免并发写导致的写冲突。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/12525
 func (a *api) UpdateFriends(userId string, friends ...*Friend) (results []*Result, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if len(friends) == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the friends is not set")
 		return
@@ -380,6 +433,11 @@ func (a *api) UpdateFriends(userId string, friends ...*Friend) (results []*Resul
 	req := &updateFriendsReq{UserId: userId, Friends: make([]*updateFriendItem, 0, len(friends))}
 
 	for _, friend := range friends {
+		if friend.GetUserId() == "" {
+			err = errNotSetAccount
+			return
+		}
+
 		item := new(updateFriendItem)
 		item.UserId = friend.GetUserId()
 
@@ -401,6 +459,11 @@ func (a *api) UpdateFriends(userId string, friends ...*Friend) (results []*Resul
 			})
 		}
 
+		if len(item.Attrs) == 0 {
+			err = errNotSetUpdateAttrs
+			return
+		}
+
 		req.Friends = append(req.Friends, item)
 	}
 
@@ -439,23 +502,41 @@ func (a *api) DeleteFriend(userId string, isBothDelete bool, deletedUserId strin
 
 // DeleteFriends 删除多个好友
 // 删除好友，支持单向删除好友和双向删除好友。
+// isBothDelete 为 true 时双向删除（Delete_Type_Both），否则单向删除（Delete_Type_Single）。
+// 待删除的好友超过1000个时将自动分批请求，并合并每个好友的处理结果。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1644
 func (a *api) DeleteFriends(userId string, isBothDelete bool, deletedUserIds ...string) (results []*Result, err error) {
-	req := &deleteFriendsReq{UserId: userId, DeletedUserIds: deletedUserIds}
-	resp := &deleteFriendsResp{}
-
-	if isBothDelete {
-		req.DeleteType = DeleteTypeBoth
-	} else {
-		req.DeleteType = DeleteTypeSingle
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
 	}
 
-	if err = a.client.Post(service, commandDeleteFriend, req, resp); err != nil {
+	if len(deletedUserIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the accounts is not set")
 		return
 	}
 
-	results = resp.Results
+	deleteType := DeleteTypeSingle
+	if isBothDelete {
+		deleteType = DeleteTypeBoth
+	}
+
+	for i := 0; i < len(deletedUserIds); i += batchDeleteFriendsLimit {
+		end := i + batchDeleteFriendsLimit
+		if end > len(deletedUserIds) {
+			end = len(deletedUserIds)
+		}
+
+		req := &deleteFriendsReq{UserId: userId, DeletedUserIds: deletedUserIds[i:end], DeleteType: deleteType}
+		resp := &deleteFriendsResp{}
+
+		if err = a.client.Post(service, commandDeleteFriend, req, resp); err != nil {
+			return
+		}
+
+		results = append(results, resp.Results...)
+	}
 
 	return
 }
@@ -465,12 +546,21 @@ func (a *api) DeleteFriends(userId string, isBothDelete bool, deletedUserIds ...
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1645
 func (a *api) DeleteAllFriends(userId string, deleteType ...DeleteType) (err error) {
-	req := &deleteAllFriendsReq{UserId: userId}
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	req := &deleteAllFriendsReq{UserId: userId, DeleteType: DeleteTypeSingle}
 
 	if len(deleteType) > 0 {
-		req.DeleteType = deleteType[0]
-	} else {
-		req.DeleteType = DeleteTypeSingle
+		switch deleteType[0] {
+		case DeleteTypeSingle, DeleteTypeBoth:
+			req.DeleteType = deleteType[0]
+		default:
+			err = core.NewError(enum.InvalidParamsCode, "invalid delete type")
+			return
+		}
 	}
 
 	if err = a.client.Post(service, commandDeleteAllFriend, req, &types.ActionBaseResp{}); err != nil {
@@ -513,6 +603,16 @@ func (a *api) CheckFriend(userId string, checkType CheckType, checkedUserId stri
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1646
 func (a *api) CheckFriends(userId string, checkType CheckType, checkedUserIds ...string) (results []*CheckResult, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	if checkType != CheckTypeSingle && checkType != CheckTypeBoth {
+		err = core.NewError(enum.InvalidParamsCode, "invalid check type")
+		return
+	}
+
 	if c := len(checkedUserIds); c == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the accounts is not set")
 		return
@@ -533,6 +633,41 @@ func (a *api) CheckFriends(userId string, checkType CheckType, checkedUserIds ..
 	return
 }
 
+// CheckFriendRelations 批量获取好友关系
+// 本方法拓展于“校验多个好友（CheckFriends）”方法。
+// 返回以被校验用户 UserID 为键、好友关系（CheckResultTypeNoRelation、CheckResultTypeAWithB、CheckResultTypeBWithA、CheckResultTypeBothWay）为值的映射。
+// 被校验用户超过100个时将自动分批请求，校验失败的用户不会出现在返回结果中。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1646
+func (a *api) CheckFriendRelations(userId string, checkType CheckType, checkedUserIds ...string) (relations map[string]string, err error) {
+	if len(checkedUserIds) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the accounts is not set")
+		return
+	}
+
+	relations = make(map[string]string, len(checkedUserIds))
+
+	for i := 0; i < len(checkedUserIds); i += batchCheckFriendsLimit {
+		end := i + batchCheckFriendsLimit
+		if end > len(checkedUserIds) {
+			end = len(checkedUserIds)
+		}
+
+		var results []*CheckResult
+		if results, err = a.CheckFriends(userId, checkType, checkedUserIds[i:end]...); err != nil {
+			return
+		}
+
+		for _, result := range results {
+			if result.ResultCode == enum.SuccessCode {
+				relations[result.UserId] = result.Relation
+			}
+		}
+	}
+
+	return
+}
+
 // GetFriend 拉取单个指定好友
 // 本方法拓展于“拉取多个指定好友（GetFriends）”方法。
 // 支持拉取指定好友的好友数据和资料数据。
@@ -558,6 +693,11 @@ func (a *api) GetFriend(userId string, tagList []string, friendUserId string) (f
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/8609
 func (a *api) GetFriends(userId string, tagList []string, friendUserIds ...string) (friends []*Friend, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if c := len(friendUserIds); c == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the account of friends is not set")
 		return
@@ -577,6 +717,11 @@ func (a *api) GetFriends(userId string, tagList []string, friendUserIds ...strin
 		}
 	}
 
+	if len(req.TagList) == 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the tag list is not set")
+		return
+	}
+
 	if err = a.client.Post(service, commandGetFriend, req, resp); err != nil {
 		return
 	}
@@ -602,6 +747,16 @@ func (a *api) GetFriends(userId string, tagList []string, friendUserIds ...strin
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1647
 func (a *api) FetchFriends(userId string, startIndex int, sequence ...int) (ret *FetchFriendsRet, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	if startIndex < 0 {
+		err = core.NewError(enum.InvalidParamsCode, "invalid start index")
+		return
+	}
+
 	req := &fetchFriendsReq{UserId: userId, StartIndex: startIndex}
 	resp := &fetchFriendsResp{}
 
@@ -670,11 +825,66 @@ func (a *api) PullFriends(userId string, fn func(ret *FetchFriendsRet)) (err err
 	return
 }
 
+// FetchFriendsWithProfiles 拉取好友及指定字段
+// 本方法拓展于“拉取好友（FetchFriends）”和“拉取多个指定好友（GetFriends）”方法。
+// 分页拉取好友数据，并为当前页的好友补充 tagList 指定的资料字段及好友字段。
+// tagList 为空时等同于“拉取好友（FetchFriends）”方法。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1647
+func (a *api) FetchFriendsWithProfiles(userId string, startIndex int, tagList []string) (ret *FetchFriendsRet, err error) {
+	if ret, err = a.FetchFriends(userId, startIndex); err != nil {
+		return
+	}
+
+	if len(tagList) == 0 || len(ret.List) == 0 {
+		return
+	}
+
+	for i := 0; i < len(ret.List); i += batchGetFriendsLimit {
+		end := i + batchGetFriendsLimit
+		if end > len(ret.List) {
+			end = len(ret.List)
+		}
+
+		mp := make(map[string]*Friend, end-i)
+		userIds := make([]string, 0, end-i)
+		for _, friend := range ret.List[i:end] {
+			mp[friend.GetUserId()] = friend
+			userIds = append(userIds, friend.GetUserId())
+		}
+
+		var friends []*Friend
+		if friends, err = a.GetFriends(userId, tagList, userIds...); err != nil {
+			return
+		}
+
+		for _, item := range friends {
+			friend, ok := mp[item.GetUserId()]
+			if !ok || item.GetError() != nil {
+				continue
+			}
+
+			for _, tag := range tagList {
+				if v, exist := item.GetAttr(tag); exist {
+					friend.SetAttr(tag, v)
+				}
+			}
+		}
+	}
+
+	return
+}
+
 // AddBlacklist 添加黑名单
 // 添加黑名单，支持批量添加黑名单。
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3718
 func (a *api) AddBlacklist(userId string, blackedUserIds ...string) (results []*Result, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if c := len(blackedUserIds); c == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the blacked accounts is not set")
 		return
@@ -700,6 +910,11 @@ func (a *api) AddBlacklist(userId string, blackedUserIds ...string) (results []*
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3719
 func (a *api) DeleteBlacklist(userId string, deletedUserIds ...string) (results []*Result, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
 	if c := len(deletedUserIds); c == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the deleted accounts is not set")
 		return
@@ -725,6 +940,16 @@ func (a *api) DeleteBlacklist(userId string, deletedUserIds ...string) (results
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3722
 func (a *api) FetchBlacklist(userId string, maxLimited int, startIndexAndSequence ...int) (ret *FetchBlacklistRet, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	if maxLimited <= 0 {
+		err = core.NewError(enum.InvalidParamsCode, "the max limited must be greater than 0")
+		return
+	}
+
 	req := &fetchBlacklistReq{UserId: userId, MaxLimited: maxLimited}
 
 	if len(startIndexAndSequence) > 0 {
@@ -784,6 +1009,16 @@ func (a *api) PullBlacklist(userId string, maxLimited int, fn func(ret *FetchBla
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3725
 func (a *api) CheckBlacklist(userId string, checkType BlacklistCheckType, checkedUserIds ...string) (results []*CheckResult, err error) {
+	if userId == "" {
+		err = core.NewError(enum.InvalidParamsCode, "the userid is not set")
+		return
+	}
+
+	if checkType != BlacklistCheckTypeSingle && checkType != BlacklistCheckTypeBoth {
+		err = core.NewError(enum.InvalidParamsCode, "invalid check type")
+		return
+	}
+
 	if c := len(checkedUserIds); c == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the checked accounts is not set")
 		return
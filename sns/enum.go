@@ -7,7 +7,10 @@
 
 package sns
 
-import "github.com/dobyte/tencent-im/internal/enum"
+import (
+	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
+)
 
 type (
 	// AddType 添加类型
@@ -88,3 +91,11 @@ const (
 	BlackCheckResultTypeBWithA  = "BlackCheckResult_Type_BWithA"  // From_Account 的黑名单中没有 To_Account，但 To_Account 的黑名单中有 From_Account
 	BlackCheckResultTypeBothWay = "BlackCheckResult_Type_BothWay" // From_Account 的黑名单中有 To_Account，To_Account 的黑名单中也有 From_Account
 )
+
+var (
+	// ErrAlreadyFriends 对方已经是好友
+	ErrAlreadyFriends = core.NewError(30015, "the users are already friends")
+
+	// ErrAddFriendPending 对方设置了加好友需要验证，等待对方处理
+	ErrAddFriendPending = core.NewError(30539, "waiting for the peer to confirm the friend request")
+)
@@ -16,8 +16,9 @@ import (
 )
 
 var (
-	errNotSetAccount   = errors.New("the friend's account is not set")
-	errNotSetAddSource = errors.New("the friend's add source is not set")
+	errNotSetAccount     = errors.New("the friend's account is not set")
+	errNotSetAddSource   = errors.New("the friend's add source is not set")
+	errNotSetUpdateAttrs = errors.New("the friend's attributes to update is not set")
 )
 
 type Friend struct {
@@ -45,7 +46,7 @@ func (f *Friend) SetAddSource(addSource string) {
 func (f *Friend) GetAddSource() (addSource string, exist bool) {
 	var v interface{}
 	if v, exist = f.GetAttr(FriendAttrAddSource); exist {
-		addSource = strings.TrimLeft(v.(string), "AddSource_Type_")
+		addSource = strings.TrimPrefix(v.(string), "AddSource_Type_")
 	}
 
 	return
@@ -85,7 +86,10 @@ func (f *Friend) SetGroup(groupName ...string) {
 func (f *Friend) GetGroup() (groups []string, exist bool) {
 	var v interface{}
 	if v, exist = f.GetAttr(FriendAttrGroup); exist && v != nil {
-		if vv, ok := v.([]interface{}); ok {
+		switch vv := v.(type) {
+		case []string:
+			groups = vv
+		case []interface{}:
 			for _, group := range vv {
 				groups = append(groups, group.(string))
 			}
@@ -119,7 +123,7 @@ func (f *Friend) SetAddTime(addTime int64) {
 func (f *Friend) GetAddTime() (addTime int64, exist bool) {
 	var v interface{}
 	if v, exist = f.GetAttr(FriendAttrAddTime); exist {
-		addTime = v.(int64)
+		addTime = toInt64(v)
 	}
 
 	return
@@ -134,7 +138,7 @@ func (f *Friend) SetRemarkTime(remarkTime int64) {
 func (f *Friend) GetRemarkTime() (remarkTime int64, exist bool) {
 	var v interface{}
 	if v, exist = f.GetAttr(FriendAttrRemarkTime); exist {
-		remarkTime = v.(int64)
+		remarkTime = toInt64(v)
 	}
 
 	return
@@ -204,3 +208,17 @@ func (f *Friend) checkError() error {
 
 	return nil
 }
+
+// toInt64 转换时间戳，兼容本地设置的int64和接口返回的float64
+func toInt64(v interface{}) int64 {
+	switch val := v.(type) {
+	case int64:
+		return val
+	case float64:
+		return int64(val)
+	case int:
+		return int64(val)
+	default:
+		return 0
+	}
+}
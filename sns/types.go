@@ -7,7 +7,11 @@
 
 package sns
 
-import "github.com/dobyte/tencent-im/internal/types"
+import (
+	"github.com/dobyte/tencent-im/internal/core"
+	"github.com/dobyte/tencent-im/internal/enum"
+	"github.com/dobyte/tencent-im/internal/types"
+)
 
 type (
 	// 添加的好友信息
@@ -299,3 +303,12 @@ type (
 		UserIds      []string `json:"To_Account"`   // 该分组下的好友的 UserID
 	}
 )
+
+// Err 获取处理结果对应的错误，处理成功时返回nil
+func (r *Result) Err() error {
+	if r.ResultCode == enum.SuccessCode {
+		return nil
+	}
+
+	return core.NewError(r.ResultCode, r.ResultInfo)
+}